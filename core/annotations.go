@@ -0,0 +1,135 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RouterAnnotation is a swag @Router line: @Router /users/{id} [get].
+type RouterAnnotation struct {
+	Path   string
+	Method string // upper-case HTTP verb
+}
+
+// ResponseHeader is a swag @Header line: @Header 200 {string} X-Request-Id "request id".
+type ResponseHeader struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// parseRouterAnnotation parses the rest of an @Router line.
+func parseRouterAnnotation(rest string, info *HandlerInfo) {
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return
+	}
+	method := "GET"
+	if len(fields) > 1 {
+		method = strings.ToUpper(strings.Trim(fields[1], "[]"))
+	}
+	info.Routers = append(info.Routers, RouterAnnotation{Path: fields[0], Method: method})
+}
+
+// parseSecurityAnnotation parses the rest of an @Security line. Each line is an
+// alternative; schemes joined with && are required together, and || separates
+// alternatives on one line: @Security OAuth2[read, write] && ApiKeyAuth.
+func parseSecurityAnnotation(rest string, info *HandlerInfo) {
+	for _, alternative := range strings.Split(rest, "||") {
+		requirement := make(map[string][]string)
+		for _, part := range strings.Split(alternative, "&&") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			name, scopes := part, []string{}
+			if open := strings.Index(part, "["); open >= 0 && strings.HasSuffix(part, "]") {
+				name = strings.TrimSpace(part[:open])
+				for _, scope := range splitCSV(part[open+1 : len(part)-1]) {
+					if scope != "" {
+						scopes = append(scopes, scope)
+					}
+				}
+			}
+			requirement[name] = scopes
+		}
+		if len(requirement) > 0 {
+			info.Security = append(info.Security, requirement)
+		}
+	}
+}
+
+// parseHeaderAnnotation parses the rest of an @Header line. The status may list
+// several codes (200,201) or be "all".
+func parseHeaderAnnotation(rest string, info *HandlerInfo) {
+	parts := splitAnnotationFields(rest)
+	if len(parts) < 3 {
+		return
+	}
+	header := ResponseHeader{Name: parts[2], Type: cleanTypeToken(parts[1])}
+	if len(parts) > 3 {
+		header.Description = strings.Join(parts[3:], " ")
+	}
+	if info.ResponseHeaders == nil {
+		info.ResponseHeaders = make(map[string][]ResponseHeader)
+	}
+	for _, status := range splitCSV(parts[0]) {
+		if status != "" {
+			info.ResponseHeaders[status] = append(info.ResponseHeaders[status], header)
+		}
+	}
+}
+
+// applyParamAttribute applies a swag @Param attribute such as Enums(a, b),
+// default(10), minimum(1), maximum(100), minlength(3), maxlength(8),
+// format(email) or example(42) to p. It reports whether attr is one.
+func applyParamAttribute(attr string, p *Parameter) bool {
+	open := strings.Index(attr, "(")
+	if open <= 0 || !strings.HasSuffix(attr, ")") {
+		return false
+	}
+	value := strings.TrimSpace(attr[open+1 : len(attr)-1])
+	switch strings.ToLower(attr[:open]) {
+	case "enums":
+		p.Enum = splitCSV(value)
+	case "default":
+		p.Default = value
+	case "example":
+		p.Example = value
+	case "minimum":
+		p.Minimum = floatArg([]string{value}, 0)
+	case "maximum":
+		p.Maximum = floatArg([]string{value}, 0)
+	case "minlength":
+		p.MinLength = intArg([]string{value}, 0)
+	case "maxlength":
+		p.MaxLength = intArg([]string{value}, 0)
+	case "format":
+		p.Format = value
+	case "collectionformat", "extensions", "validate":
+		// Accepted for compatibility; they do not change the schema.
+	default:
+		return false
+	}
+	return true
+}
+
+// annotationValue converts an annotation value to the JSON type of the schema
+// type, keeping it a string when it does not parse.
+func annotationValue(raw, schemaType string) interface{} {
+	switch schemaType {
+	case "integer":
+		if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(raw, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(raw); err == nil {
+			return v
+		}
+	}
+	return raw
+}
@@ -0,0 +1,302 @@
+package core
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// generalInfo holds swag's general API annotations (@title, @version,
+// @host, @securityDefinitions.*, ...) read from the main package.
+type generalInfo struct {
+	title          string
+	version        string
+	description    string
+	termsOfService string
+	contact        map[string]interface{}
+	license        map[string]interface{}
+	host           string
+	basePath       string
+	schemes        []string
+
+	securitySchemes map[string]map[string]interface{}
+}
+
+// findGeneralInfo returns the general API annotations of the first main
+// package file under dirs that has any, or nil. Directories are walked like
+// route discovery does, in lexical order.
+func findGeneralInfo(dirs []string) *generalInfo {
+	var found *generalInfo
+	seen := make(map[string]struct{})
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if found != nil {
+				return filepath.SkipAll
+			}
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if path != dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "testdata" || d.Name() == "vendor") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+			if _, dup := seen[path]; dup {
+				return nil
+			}
+			seen[path] = struct{}{}
+			found = generalInfoFromFile(path)
+			return nil
+		})
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func generalInfoFromFile(path string) *generalInfo {
+	fset := token.NewFileSet()
+	if file, err := parser.ParseFile(fset, path, nil, parser.PackageClauseOnly); err != nil || file.Name.Name != "main" {
+		return nil
+	}
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	var groups [][]string
+	for _, group := range file.Comments {
+		var lines []string
+		for _, comment := range group.List {
+			line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			if strings.HasPrefix(line, "@") {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 && !isOperationComment(lines) {
+			groups = append(groups, lines)
+		}
+	}
+	return parseGeneralInfo(groups)
+}
+
+// operationTags are the annotations that make a comment group a handler's, as
+// swag's isGeneralAPIComment decides; such groups hold no general info.
+var operationTags = map[string]struct{}{
+	"@summary":  {},
+	"@router":   {},
+	"@param":    {},
+	"@success":  {},
+	"@failure":  {},
+	"@response": {},
+	"@id":       {},
+	"@tags":     {},
+	"@accept":   {},
+	"@produce":  {},
+}
+
+func isOperationComment(lines []string) bool {
+	for _, line := range lines {
+		tag := line
+		if idx := strings.IndexAny(line, " \t"); idx >= 0 {
+			tag = line[:idx]
+		}
+		if _, ok := operationTags[strings.ToLower(tag)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseGeneralInfo interprets the annotation lines of comment groups. Lines
+// following an @securityDefinitions.* line of the same group (@in, @name,
+// @tokenUrl, @scope.read, ...) describe that scheme. It returns nil when the
+// groups hold no general annotation.
+func parseGeneralInfo(groups [][]string) *generalInfo {
+	info := &generalInfo{}
+	found := false
+	for _, lines := range groups {
+		if parseGeneralInfoGroup(info, lines) {
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return info
+}
+
+// parseGeneralInfoGroup applies the lines of one comment group to info and
+// reports whether any of them is a general annotation.
+func parseGeneralInfoGroup(info *generalInfo, lines []string) bool {
+	found := false
+	var scheme map[string]interface{} // security scheme being declared
+	for _, line := range lines {
+		tag, value := line, ""
+		if idx := strings.IndexAny(line, " \t"); idx >= 0 {
+			tag, value = line[:idx], strings.TrimSpace(line[idx+1:])
+		}
+		key := strings.ToLower(tag)
+
+		if strings.HasPrefix(key, "@securitydefinitions.") && value != "" {
+			scheme = newSecurityScheme(strings.TrimPrefix(key, "@securitydefinitions."))
+			if scheme != nil {
+				if info.securitySchemes == nil {
+					info.securitySchemes = make(map[string]map[string]interface{})
+				}
+				info.securitySchemes[value] = scheme
+				found = true
+			}
+			continue
+		}
+		if scheme != nil && applySecuritySchemeAttribute(scheme, tag, value) {
+			continue
+		}
+
+		switch key {
+		case "@title":
+			info.title = value
+		case "@version":
+			info.version = value
+		case "@description":
+			if info.description != "" {
+				info.description += "\n"
+			}
+			info.description += value
+		case "@termsofservice":
+			info.termsOfService = value
+		case "@contact.name", "@contact.url", "@contact.email":
+			if info.contact == nil {
+				info.contact = make(map[string]interface{})
+			}
+			info.contact[strings.TrimPrefix(key, "@contact.")] = value
+		case "@license.name", "@license.url":
+			if info.license == nil {
+				info.license = make(map[string]interface{})
+			}
+			info.license[strings.TrimPrefix(key, "@license.")] = value
+		case "@host":
+			info.host = value
+		case "@basepath":
+			info.basePath = value
+		case "@schemes":
+			info.schemes = strings.Fields(value)
+		default:
+			continue
+		}
+		found = true
+	}
+	return found
+}
+
+// oauthFlows maps swag's OAuth2 definition kinds to OpenAPI 3 flow names.
+var oauthFlows = map[string]string{
+	"oauth2.application": "clientCredentials",
+	"oauth2.implicit":    "implicit",
+	"oauth2.password":    "password",
+	"oauth2.accesscode":  "authorizationCode",
+}
+
+func newSecurityScheme(kind string) map[string]interface{} {
+	switch kind {
+	case "basic":
+		return map[string]interface{}{"type": "http", "scheme": "basic"}
+	case "apikey":
+		return map[string]interface{}{"type": "apiKey"}
+	}
+	if flow, ok := oauthFlows[kind]; ok {
+		return map[string]interface{}{
+			"type": "oauth2",
+			"flows": map[string]interface{}{
+				flow: map[string]interface{}{"scopes": map[string]interface{}{}},
+			},
+		}
+	}
+	return nil
+}
+
+// applySecuritySchemeAttribute applies an attribute line to the scheme being
+// declared and reports whether tag is one.
+func applySecuritySchemeAttribute(scheme map[string]interface{}, tag, value string) bool {
+	key := strings.ToLower(tag)
+	switch key {
+	case "@in", "@name":
+		scheme[strings.TrimPrefix(key, "@")] = value
+		return true
+	case "@description":
+		scheme["description"] = value
+		return true
+	}
+	flows, ok := scheme["flows"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, f := range flows {
+		flow := f.(map[string]interface{})
+		switch {
+		case key == "@tokenurl":
+			flow["tokenUrl"] = value
+		case key == "@authorizationurl":
+			flow["authorizationUrl"] = value
+		case strings.HasPrefix(key, "@scope."):
+			// Scope names are matched by @Security as written.
+			flow["scopes"].(map[string]interface{})[tag[len("@scope."):]] = value
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// servers returns the OpenAPI servers for @host, @BasePath and @schemes.
+// Without @schemes the host is given scheme-relative.
+func (g *generalInfo) servers(basePath string) []map[string]interface{} {
+	if g.host == "" {
+		if basePath == "" {
+			return nil
+		}
+		return []map[string]interface{}{{"url": basePath}}
+	}
+	schemes := g.schemes
+	if len(schemes) == 0 {
+		schemes = []string{""}
+	}
+	servers := make([]map[string]interface{}, 0, len(schemes))
+	for _, scheme := range schemes {
+		url := "//" + g.host + basePath
+		if scheme != "" {
+			url = scheme + ":" + url
+		}
+		servers = append(servers, map[string]interface{}{"url": url})
+	}
+	return servers
+}
+
+// stripBasePath makes paths relative to basePath when every path lies under
+// it, and reports whether it did.
+func stripBasePath(paths map[string]PathItem, basePath string) (map[string]PathItem, bool) {
+	basePath = strings.TrimSuffix(basePath, "/")
+	if basePath == "" {
+		return paths, false
+	}
+	for path := range paths {
+		if !hasPathPrefix(path, basePath) {
+			return paths, false
+		}
+	}
+	stripped := make(map[string]PathItem, len(paths))
+	for path, item := range paths {
+		rel := strings.TrimPrefix(path, basePath)
+		if rel == "" {
+			rel = "/"
+		}
+		stripped[rel] = item
+	}
+	return stripped, true
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// recordTypeDoc remembers the doc comment of ts. For an unparenthesized
+// declaration (type User struct{...}) the parser attaches it to gen.
+func (r *TypeRegistry) recordTypeDoc(gen *ast.GenDecl, ts *ast.TypeSpec) {
+	if r == nil || ts == nil {
+		return
+	}
+	doc := ts.Doc
+	if doc == nil && gen != nil && !gen.Lparen.IsValid() {
+		doc = gen.Doc
+	}
+	if doc == nil {
+		doc = ts.Comment
+	}
+	if doc == nil {
+		return
+	}
+	if r.typeDocs == nil {
+		r.typeDocs = make(map[*ast.TypeSpec]*ast.CommentGroup)
+	}
+	r.typeDocs[ts] = doc
+}
+
+// typeDoc returns the doc comment recorded for spec.
+func (r *TypeRegistry) typeDoc(spec *ast.TypeSpec) *ast.CommentGroup {
+	if r == nil || spec == nil {
+		return nil
+	}
+	if doc := r.typeDocs[spec]; doc != nil {
+		return doc
+	}
+	return spec.Doc
+}
+
+// commentDescription returns the text of the first non-empty comment group and
+// whether it has a paragraph starting with "Deprecated:", as Go tooling reads it.
+func commentDescription(groups ...*ast.CommentGroup) (string, bool) {
+	for _, group := range groups {
+		text := strings.TrimSpace(group.Text())
+		if text == "" {
+			continue
+		}
+		deprecated := false
+		for _, paragraph := range strings.Split(text, "\n\n") {
+			if strings.HasPrefix(strings.TrimSpace(paragraph), "Deprecated:") {
+				deprecated = true
+			}
+		}
+		return text, deprecated
+	}
+	return "", false
+}
+
+// describeSchema adds the description and deprecation given by the doc comment
+// of a type declaration or struct field to its schema. A $ref cannot carry
+// siblings in OpenAPI 3.0, so it is wrapped in an allOf.
+func describeSchema(schema map[string]interface{}, groups ...*ast.CommentGroup) map[string]interface{} {
+	description, deprecated := commentDescription(groups...)
+	if description == "" {
+		return schema
+	}
+	if _, isRef := schema["$ref"]; isRef {
+		schema = map[string]interface{}{"allOf": []interface{}{schema}}
+	}
+	schema["description"] = description
+	if deprecated {
+		schema["deprecated"] = true
+	}
+	return schema
+}
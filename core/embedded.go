@@ -0,0 +1,224 @@
+package core
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// structProperty is a JSON property of a struct and where encoding/json finds it.
+type structProperty struct {
+	name     string
+	schema   map[string]interface{}
+	required bool
+	depth    int
+	tagged   bool
+}
+
+// fieldSchemaFunc returns the schema of a field type declared in pkg, or nil
+// when the field cannot be described.
+type fieldSchemaFunc func(expr ast.Expr, pkg string) map[string]interface{}
+
+// structObjectSchema builds the object schema of st, following encoding/json
+// for embedded fields. With ProjectConfig.EmbeddedAllOf embedded structs are
+// composed with allOf instead of being flattened. b may be nil, in which case
+// embedded types cannot be resolved and are kept as named properties.
+func structObjectSchema(st *ast.StructType, pkg string, b *componentBuilder, fieldSchema fieldSchemaFunc) map[string]interface{} {
+	var refs []interface{}
+	var props []structProperty
+	if st != nil {
+		props = collectStructProperties(st, pkg, b, fieldSchema, 0, false, &refs, make(map[string]struct{}))
+	}
+
+	properties := make(map[string]interface{})
+	var required []string
+	for _, p := range dominantProperties(props) {
+		properties[p.name] = p.schema
+		if p.required {
+			required = append(required, p.name)
+		}
+	}
+	schema := map[string]interface{}{"type": "object"}
+	if len(properties) > 0 {
+		schema["properties"] = properties
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	if len(refs) == 0 {
+		return schema
+	}
+	if len(properties) > 0 {
+		refs = append(refs, schema)
+	}
+	return map[string]interface{}{"allOf": refs}
+}
+
+// collectStructProperties returns the properties of st at depth, descending
+// into embedded structs. In allOf mode the embedded structs of the outermost
+// struct are added to refs instead. seen guards against embedding cycles.
+func collectStructProperties(st *ast.StructType, pkg string, b *componentBuilder, fieldSchema fieldSchemaFunc, depth int, optional bool, refs *[]interface{}, seen map[string]struct{}) []structProperty {
+	if st.Fields == nil {
+		return nil
+	}
+	var props []structProperty
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			props = append(props, embeddedProperties(field, pkg, b, fieldSchema, depth, optional, refs, seen)...)
+			continue
+		}
+		for _, name := range field.Names {
+			if name == nil || name.Name == "" {
+				continue
+			}
+			meta := extractJSONMetadata(field, name.Name)
+			if meta.skip || meta.name == "" {
+				continue
+			}
+			schema := fieldSchema(field.Type, pkg)
+			if schema == nil {
+				continue
+			}
+			fieldOptional := meta.omitEmpty || isPointerType(field.Type)
+			if v := applyFieldValidation(schema, field); v.required || v.optional {
+				fieldOptional = v.optional
+			}
+			props = append(props, structProperty{
+				name:     meta.name,
+				schema:   describeSchema(schema, field.Doc, field.Comment),
+				required: !fieldOptional && !optional,
+				depth:    depth,
+				tagged:   jsonTagName(field) != "",
+			})
+		}
+	}
+	return props
+}
+
+// embeddedProperties returns the properties contributed by an embedded field:
+// the fields of an untagged embedded struct, or pointer to one, are promoted
+// and an embed with a json name is an ordinary property. Promoted fields of
+// embedded pointers are optional, since a nil pointer omits them.
+func embeddedProperties(field *ast.Field, pkg string, b *componentBuilder, fieldSchema fieldSchemaFunc, depth int, optional bool, refs *[]interface{}, seen map[string]struct{}) []structProperty {
+	typeExpr := field.Type
+	pointer := false
+	if star, ok := typeExpr.(*ast.StarExpr); ok {
+		typeExpr, pointer = star.X, true
+	}
+	typeName := exprToString(typeExpr)
+	if b != nil {
+		if typed := strings.TrimPrefix(b.registry.typedExprType(typeExpr), "*"); typed != "" {
+			typeName = typed
+		}
+	}
+	simpleName := typeName[strings.LastIndex(typeName, ".")+1:]
+
+	meta := extractJSONMetadata(field, "")
+	if meta.skip {
+		return nil
+	}
+
+	var spec *TypeSpecInfo
+	var key string
+	if b != nil && meta.name == "" {
+		spec, key = b.registry.Resolve(typeName, pkg)
+	}
+	structType, isStruct := (*ast.StructType)(nil), false
+	if spec != nil && spec.Spec != nil {
+		structType, isStruct = spec.Spec.Type.(*ast.StructType)
+	}
+
+	if !isStruct {
+		// Tagged embeds and embedded non-struct types are properties named
+		// after the tag or the type; unexported ones are not encoded.
+		name := meta.name
+		if name == "" {
+			if !ast.IsExported(simpleName) {
+				return nil
+			}
+			name = simpleName
+		}
+		schema := fieldSchema(field.Type, pkg)
+		if schema == nil {
+			return nil
+		}
+		fieldOptional := meta.omitEmpty || pointer
+		if v := applyFieldValidation(schema, field); v.required || v.optional {
+			fieldOptional = v.optional
+		}
+		return []structProperty{{
+			name:     name,
+			schema:   describeSchema(schema, field.Doc, field.Comment),
+			required: !fieldOptional && !optional,
+			depth:    depth,
+			tagged:   meta.name != "",
+		}}
+	}
+
+	if b.embeddedAllOf && depth == 0 {
+		compName := b.ensureComponent(typeName, pkg)
+		*refs = append(*refs, map[string]interface{}{"$ref": "#/components/schemas/" + compName})
+		return nil
+	}
+	if _, cycle := seen[key]; cycle {
+		return nil
+	}
+	seen[key] = struct{}{}
+	defer delete(seen, key)
+	return collectStructProperties(structType, spec.Package, b, fieldSchema, depth+1, optional || pointer, refs, seen)
+}
+
+// dominantProperties resolves properties sharing a name the way encoding/json
+// does, keeping declaration order: the shallowest wins and, at equal depth, a
+// tagged one wins over untagged ones; otherwise the name is dropped.
+func dominantProperties(props []structProperty) []structProperty {
+	byName := make(map[string][]structProperty)
+	var order []string
+	for _, p := range props {
+		if _, ok := byName[p.name]; !ok {
+			order = append(order, p.name)
+		}
+		byName[p.name] = append(byName[p.name], p)
+	}
+	var out []structProperty
+	for _, name := range order {
+		candidates := byName[name]
+		if len(candidates) == 1 {
+			out = append(out, candidates[0])
+			continue
+		}
+		minDepth := candidates[0].depth
+		for _, p := range candidates[1:] {
+			if p.depth < minDepth {
+				minDepth = p.depth
+			}
+		}
+		var shallow, tagged []structProperty
+		for _, p := range candidates {
+			if p.depth != minDepth {
+				continue
+			}
+			shallow = append(shallow, p)
+			if p.tagged {
+				tagged = append(tagged, p)
+			}
+		}
+		switch {
+		case len(shallow) == 1:
+			out = append(out, shallow[0])
+		case len(tagged) == 1:
+			out = append(out, tagged[0])
+		}
+	}
+	return out
+}
+
+// jsonTagName returns the name given by the json tag of field, if any.
+func jsonTagName(field *ast.Field) string {
+	name, _, _ := strings.Cut(extractTag(field, "json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
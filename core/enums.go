@@ -0,0 +1,181 @@
+package core
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strconv"
+)
+
+// enumValue is one typed constant of an enum type.
+type enumValue struct {
+	name  string
+	value interface{} // string or int64
+}
+
+// enumKey identifies a type by the directory of its package, which keeps
+// packages sharing a name apart.
+func enumKey(file, typeName string) string {
+	return filepath.Dir(file) + "." + typeName
+}
+
+// addEnums records the typed constants declared in file, which document their
+// named type as an enum:
+//
+//	type OrderStatus string
+//
+//	const (
+//		StatusPending OrderStatus = "pending"
+//		StatusPaid    OrderStatus = "paid"
+//	)
+//
+// Integer constants may use iota.
+func (r *TypeRegistry) addEnums(path string, file *ast.File) {
+	if r == nil || file == nil {
+		return
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		var (
+			typeName string
+			values   []ast.Expr
+		)
+		for index, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			// A spec without type and values repeats the previous ones.
+			if vs.Type != nil || len(vs.Values) > 0 {
+				typeName, values = "", vs.Values
+				if ident, ok := vs.Type.(*ast.Ident); ok {
+					typeName = ident.Name
+				}
+			}
+			for i, name := range vs.Names {
+				if name.Name == "_" || i >= len(values) {
+					continue
+				}
+				owner, expr := typeName, values[i]
+				// Untyped constants may convert: StatusPaid = OrderStatus("paid").
+				if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+					if fun, ok := call.Fun.(*ast.Ident); ok && owner == "" {
+						owner, expr = fun.Name, call.Args[0]
+					}
+				}
+				if owner == "" {
+					continue
+				}
+				value, ok := constValue(expr, int64(index))
+				if !ok {
+					continue
+				}
+				r.addEnumValue(enumKey(path, owner), enumValue{name: name.Name, value: value})
+			}
+		}
+	}
+}
+
+func (r *TypeRegistry) addEnumValue(key string, value enumValue) {
+	if r.enums == nil {
+		r.enums = make(map[string][]enumValue)
+	}
+	for _, existing := range r.enums[key] {
+		if existing.name == value.name {
+			return
+		}
+	}
+	r.enums[key] = append(r.enums[key], value)
+}
+
+// enumValues returns the constants of the type described by info.
+func (r *TypeRegistry) enumValues(info *TypeSpecInfo) []enumValue {
+	if r == nil || info == nil {
+		return nil
+	}
+	return r.enums[enumKey(info.File, info.Name)]
+}
+
+// constValue evaluates a string literal or an integer expression of literals
+// and iota, which stands for index, the position of the spec in its block.
+func constValue(expr ast.Expr, index int64) (interface{}, bool) {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		s, err := strconv.Unquote(lit.Value)
+		return s, err == nil
+	}
+	n, ok := intConstValue(expr, index)
+	return n, ok
+}
+
+func intConstValue(expr ast.Expr, index int64) (int64, bool) {
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		if v.Kind != token.INT {
+			return 0, false
+		}
+		n, err := strconv.ParseInt(v.Value, 0, 64)
+		return n, err == nil
+	case *ast.Ident:
+		return index, v.Name == "iota"
+	case *ast.ParenExpr:
+		return intConstValue(v.X, index)
+	case *ast.UnaryExpr:
+		n, ok := intConstValue(v.X, index)
+		if v.Op == token.SUB {
+			n = -n
+		}
+		return n, ok && (v.Op == token.SUB || v.Op == token.ADD)
+	case *ast.BinaryExpr:
+		x, okX := intConstValue(v.X, index)
+		y, okY := intConstValue(v.Y, index)
+		if !okX || !okY {
+			return 0, false
+		}
+		switch v.Op {
+		case token.ADD:
+			return x + y, true
+		case token.SUB:
+			return x - y, true
+		case token.MUL:
+			return x * y, true
+		case token.SHL:
+			if y >= 0 && y < 63 {
+				return x << uint(y), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// applyEnumValues adds the enum and x-enum-varnames of a named type's
+// constants to its schema, keeping the constants that fit its type: OrderStatus
+// gets enum ["pending", "paid"] and x-enum-varnames ["StatusPending", "StatusPaid"].
+func applyEnumValues(schema map[string]interface{}, values []enumValue) {
+	schemaType, _ := schema["type"].(string)
+	var (
+		enum  []interface{}
+		names []string
+	)
+	for _, v := range values {
+		switch v.value.(type) {
+		case string:
+			if schemaType != "string" {
+				continue
+			}
+		case int64:
+			if schemaType != "integer" && schemaType != "number" {
+				continue
+			}
+		}
+		enum = append(enum, v.value)
+		names = append(names, v.name)
+	}
+	if len(enum) == 0 {
+		return
+	}
+	schema["enum"] = enum
+	schema["x-enum-varnames"] = names
+}
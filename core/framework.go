@@ -0,0 +1,445 @@
+package core
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RouteCall is the framework-neutral shape of a route registration call.
+type RouteCall struct {
+	Method     string     // upper-case HTTP verb
+	Path       string     // path as written at the call site, without group prefixes
+	Receiver   ast.Expr   // router/group expression the route is registered on
+	Handler    ast.Expr   // expression passed as the handler
+	Middleware []ast.Expr // route-level middleware passed with the handler
+
+	host string // host of a ServeMux pattern, which OpenAPI paths cannot express
+}
+
+// RouteFinder recognises the route registration calls of one routing framework.
+// Group prefixes are tracked by the shared scanner for Group, Route, Mount and
+// With calls, so finders only need to describe single registrations.
+type RouteFinder interface {
+	MatchRoute(call *ast.CallExpr) (RouteCall, bool)
+}
+
+// stringResolver evaluates a string expression at a route registration site:
+// literals, constants (local, package-level or imported from the module) and
+// concatenations of those.
+type stringResolver func(expr ast.Expr) (string, bool)
+
+// resolvingRouteFinder is implemented by the built-in finders so that paths
+// declared through constants are resolved in the scanned file's scope.
+type resolvingRouteFinder interface {
+	matchRouteResolved(call *ast.CallExpr, str stringResolver) (RouteCall, bool)
+}
+
+// builtinRouteFinder adapts a built-in matcher. Called through MatchRoute it
+// only accepts string literals.
+type builtinRouteFinder func(call *ast.CallExpr, str stringResolver) (RouteCall, bool)
+
+func (f builtinRouteFinder) MatchRoute(call *ast.CallExpr) (RouteCall, bool) {
+	return f(call, stringLiteral)
+}
+
+func (f builtinRouteFinder) matchRouteResolved(call *ast.CallExpr, str stringResolver) (RouteCall, bool) {
+	return f(call, str)
+}
+
+// RouteFinderFunc adapts a plain function to the RouteFinder interface.
+type RouteFinderFunc func(call *ast.CallExpr) (RouteCall, bool)
+
+// MatchRoute calls f(call).
+func (f RouteFinderFunc) MatchRoute(call *ast.CallExpr) (RouteCall, bool) {
+	return f(call)
+}
+
+// HandlerAnalyzer inspects handler bodies written against one framework's
+// request context.
+type HandlerAnalyzer interface {
+	// IsContextParam reports whether a handler parameter of the given type, as
+	// written in source (e.g. "*fiber.Ctx"), is the framework's context.
+	IsContextParam(typeName string) bool
+	// AnalyzeCall interprets a call found in the handler body and reports
+	// whether it was recognised.
+	AnalyzeCall(call *ast.CallExpr, hc *HandlerContext) bool
+}
+
+// HandlerContext is the handler being analysed, as seen by a HandlerAnalyzer.
+type HandlerContext struct {
+	Info     *HandlerInfo
+	Registry *TypeRegistry
+
+	varTypes map[string]string
+	state    *handlerState
+}
+
+// IsContextVar reports whether expr is one of the handler's context parameters.
+func (hc *HandlerContext) IsContextVar(expr ast.Expr) bool {
+	return isVarIn(expr, hc.Info.ctxVars)
+}
+
+// VarType returns the inferred type of a local variable, if known.
+func (hc *HandlerContext) VarType(name string) string {
+	return hc.varTypes[name]
+}
+
+// Status resolves a status code argument such as 201 or http.StatusCreated.
+func (hc *HandlerContext) Status(expr ast.Expr) string {
+	return normalizeStatusLiteral(expr)
+}
+
+// SetRequestBody records the request body type from a decode target such as &req.
+func (hc *HandlerContext) SetRequestBody(target ast.Expr) {
+	setInputTypeFromArg(hc.Info, target, hc.varTypes)
+}
+
+// AddQueryParam documents a query parameter; multiple marks it as repeatable.
+func (hc *HandlerContext) AddQueryParam(name string, multiple bool) {
+	ensureQueryParam(hc.Info, name, multiple)
+}
+
+// AddFormParam documents a form field; file marks it as an uploaded file.
+func (hc *HandlerContext) AddFormParam(name string, file bool) {
+	param := Parameter{Name: name, In: "formData", Type: "string"}
+	if file {
+		param.Type = "file"
+		param.Required = true
+	}
+	ensureFormParam(hc.Info, param)
+}
+
+// AddResponse documents a JSON response whose body is described by expr.
+func (hc *HandlerContext) AddResponse(status string, body ast.Expr) {
+	addResponseFromExpr(hc.Info, status, body, hc.varTypes, hc.Registry)
+}
+
+// AddEmptyResponse documents a response without a body.
+func (hc *HandlerContext) AddEmptyResponse(status string) {
+	ensureEmptyResponse(hc.Info, status)
+}
+
+// AddTextResponse documents a text/plain response.
+func (hc *HandlerContext) AddTextResponse(status string) {
+	ensureTextResponse(hc.Info, status)
+	hc.Info.Produces = appendUnique(hc.Info.Produces, "text/plain")
+}
+
+// AddBinaryResponse documents an application/octet-stream response.
+func (hc *HandlerContext) AddBinaryResponse(status string) {
+	ensureBinaryResponse(hc.Info, status)
+	hc.Info.Produces = appendUnique(hc.Info.Produces, "application/octet-stream")
+}
+
+// responseClassifier is implemented by the built-in analyzers so that
+// responses in return statements and helper calls share one classification.
+type responseClassifier interface {
+	classifyResponse(call *ast.CallExpr, info *HandlerInfo) (ctxResponseKind, string, ast.Expr)
+}
+
+// Framework bundles the route finder and handler analyzer for one framework.
+//
+// Route registrations are only documented when their receiver is known to be a
+// router: a value of one of RouterTypes or of a package type embedding one, the
+// result of one of RouterConstructors, a Group/Route/With result, a parameter
+// of a Register function whose type is declared in the framework's packages or
+// wraps a router, or the framework package itself (http.HandleFunc). Both
+// lists hold names declared in the packages under ImportPaths, e.g. "App" and
+// "New" for fiber.App and fiber.New.
+type Framework struct {
+	Name               string          // identifier used by ProjectConfig.Frameworks
+	ImportPaths        []string        // import path prefixes used for auto-detection
+	Routes             RouteFinder     // may be nil for analyzer-only frameworks
+	Handlers           HandlerAnalyzer // may be nil for router-only frameworks
+	RouterTypes        []string        // router and group type names, e.g. "App", "Router"
+	RouterConstructors []string        // functions returning a router, e.g. "New"
+	// GroupMiddleware makes Use and Group middleware guard the routes of the
+	// router value it is attached to, as in gin and echo. Otherwise it guards
+	// every route under the router's path prefix, as in Fiber.
+	GroupMiddleware bool
+}
+
+// Names of the built-in frameworks.
+const (
+	frameworkFiber   = "fiber"
+	frameworkNetHTTP = "nethttp"
+	frameworkChi     = "chi"
+	frameworkGin     = "gin"
+	frameworkEcho    = "echo"
+)
+
+var (
+	frameworksMu sync.RWMutex
+	frameworks   []Framework
+)
+
+func init() {
+	RegisterFramework(Framework{
+		Name:               frameworkFiber,
+		ImportPaths:        []string{"github.com/gofiber/fiber"},
+		Routes:             builtinRouteFinder(fiberRouteCall),
+		Handlers:           fiberAnalyzer{},
+		RouterTypes:        []string{"App", "Router", "Group"},
+		RouterConstructors: []string{"New"},
+	})
+	RegisterFramework(Framework{
+		Name:               frameworkNetHTTP,
+		ImportPaths:        []string{"net/http"},
+		Routes:             builtinRouteFinder(serveMuxRouteCall),
+		Handlers:           httpAnalyzer{},
+		RouterTypes:        []string{"ServeMux"},
+		RouterConstructors: []string{"NewServeMux"},
+	})
+	RegisterFramework(Framework{
+		Name:               frameworkChi,
+		ImportPaths:        []string{"github.com/go-chi/chi"},
+		Routes:             builtinRouteFinder(chiRouteFinder),
+		Handlers:           httpAnalyzer{},
+		RouterTypes:        []string{"Mux", "Router"},
+		RouterConstructors: []string{"NewRouter", "NewMux"},
+	})
+	RegisterFramework(Framework{
+		Name:               frameworkGin,
+		ImportPaths:        []string{"github.com/gin-gonic/gin"},
+		Routes:             builtinRouteFinder(ginRouteCall),
+		Handlers:           ginAnalyzer{},
+		RouterTypes:        []string{"Engine", "RouterGroup", "IRouter", "IRoutes"},
+		RouterConstructors: []string{"New", "Default"},
+		GroupMiddleware:    true,
+	})
+	RegisterFramework(Framework{
+		Name:               frameworkEcho,
+		ImportPaths:        []string{"github.com/labstack/echo"},
+		Routes:             builtinRouteFinder(echoRouteCall),
+		Handlers:           echoAnalyzer{},
+		RouterTypes:        []string{"Echo", "Group"},
+		RouterConstructors: []string{"New"},
+		GroupMiddleware:    true,
+	})
+}
+
+// RegisterFramework makes a framework available to route discovery and handler
+// analysis. Registering an existing name replaces the earlier entry, which lets
+// callers override the built-in fiber, nethttp, chi, gin and echo support.
+func RegisterFramework(fw Framework) {
+	fw.Name = strings.TrimSpace(fw.Name)
+	if fw.Name == "" {
+		panic("core: RegisterFramework called with an empty name")
+	}
+	frameworksMu.Lock()
+	defer frameworksMu.Unlock()
+	for i := range frameworks {
+		if frameworks[i].Name == fw.Name {
+			frameworks[i] = fw
+			return
+		}
+	}
+	frameworks = append(frameworks, fw)
+}
+
+// RegisteredFrameworks returns the names of all registered frameworks in
+// registration order.
+func RegisteredFrameworks() []string {
+	frameworksMu.RLock()
+	defer frameworksMu.RUnlock()
+	names := make([]string, 0, len(frameworks))
+	for _, fw := range frameworks {
+		names = append(names, fw.Name)
+	}
+	return names
+}
+
+// unregisterFramework removes the framework registered under name, if any.
+func unregisterFramework(name string) {
+	frameworksMu.Lock()
+	defer frameworksMu.Unlock()
+	for i := range frameworks {
+		if frameworks[i].Name == name {
+			frameworks = append(frameworks[:i:i], frameworks[i+1:]...)
+			return
+		}
+	}
+}
+
+func registeredFrameworks() []Framework {
+	frameworksMu.RLock()
+	defer frameworksMu.RUnlock()
+	return append([]Framework(nil), frameworks...)
+}
+
+// handlerAnalyzerFor returns the analyzer registered under name. Handlers whose
+// context type is not recognised fall back to the Fiber analyzer.
+func handlerAnalyzerFor(name string) HandlerAnalyzer {
+	for _, fw := range registeredFrameworks() {
+		if fw.Name == name && fw.Handlers != nil {
+			return fw.Handlers
+		}
+	}
+	return fiberAnalyzer{}
+}
+
+// handlerFramework infers which framework a handler belongs to from its parameters.
+func handlerFramework(fn *ast.FuncDecl) string {
+	if fn == nil || fn.Type == nil || fn.Type.Params == nil {
+		return ""
+	}
+	fws := registeredFrameworks()
+	for _, field := range fn.Type.Params.List {
+		typeName := strings.TrimSpace(exprToString(field.Type))
+		for _, fw := range fws {
+			if fw.Handlers != nil && fw.Handlers.IsContextParam(typeName) {
+				return fw.Name
+			}
+		}
+	}
+	return ""
+}
+
+func isContextParamType(typeName string) bool {
+	for _, fw := range registeredFrameworks() {
+		if fw.Handlers != nil && fw.Handlers.IsContextParam(typeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeFindersFor orders the finders of the given frameworks so that those
+// imported by the file come first. This keeps frameworks with overlapping
+// helper names (gin and echo both use router.GET) from shadowing each other.
+func routeFindersFor(fws []Framework, imports map[string]string) []RouteFinder {
+	var preferred, rest []RouteFinder
+	for _, fw := range fws {
+		if fw.Routes == nil {
+			continue
+		}
+		imported := false
+		for _, importPath := range imports {
+			if matchesImportPrefix(importPath, fw.ImportPaths) {
+				imported = true
+				break
+			}
+		}
+		if imported {
+			preferred = append(preferred, fw.Routes)
+		} else {
+			rest = append(rest, fw.Routes)
+		}
+	}
+	return append(preferred, rest...)
+}
+
+// importsGroupMiddleware reports whether imports include a framework with
+// GroupMiddleware.
+func importsGroupMiddleware(fws []Framework, imports map[string]string) bool {
+	for _, fw := range fws {
+		if !fw.GroupMiddleware {
+			continue
+		}
+		for _, importPath := range imports {
+			if matchesImportPrefix(importPath, fw.ImportPaths) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesImportPrefix(importPath string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if importPath == prefix || strings.HasPrefix(importPath, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// selectFrameworks resolves ProjectConfig.Frameworks. An empty list selects the
+// registered frameworks imported by Go files under paths, or all of them when
+// none is imported.
+func selectFrameworks(names []string, paths []string) ([]Framework, error) {
+	registered := registeredFrameworks()
+	var selected []Framework
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, fw := range registered {
+			if strings.EqualFold(fw.Name, name) {
+				selected = append(selected, fw)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("core: unknown framework %q (registered: %s)", name, strings.Join(RegisteredFrameworks(), ", "))
+		}
+	}
+	if len(selected) > 0 {
+		return selected, nil
+	}
+
+	imports, err := collectImportPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	for _, fw := range registered {
+		for importPath := range imports {
+			if matchesImportPrefix(importPath, fw.ImportPaths) {
+				selected = append(selected, fw)
+				break
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return registered, nil
+	}
+	return selected, nil
+}
+
+// collectImportPaths gathers the import paths of the Go files under paths,
+// skipping the same directories as route discovery.
+func collectImportPaths(paths []string) (map[string]struct{}, error) {
+	imports := make(map[string]struct{})
+	fset := token.NewFileSet()
+	for _, root := range paths {
+		walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "testdata" || d.Name() == "vendor") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+			file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+			if err != nil {
+				// Route discovery reports syntax errors; detection just skips the file.
+				return nil
+			}
+			for _, imp := range file.Imports {
+				if importPath, err := strconv.Unquote(imp.Path.Value); err == nil {
+					imports[importPath] = struct{}{}
+				}
+			}
+			return nil
+		})
+		if walkErr != nil {
+			return nil, fmt.Errorf("core: detect frameworks in %s: %w", root, walkErr)
+		}
+	}
+	return imports, nil
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// kitAnalyzer understands handlers written against a made-up in-house router:
+// func(req *kit.Request) and req.Reply(code, v).
+type kitAnalyzer struct{}
+
+func (kitAnalyzer) IsContextParam(typeName string) bool {
+	return typeName == "*kit.Request"
+}
+
+func (kitAnalyzer) AnalyzeCall(call *ast.CallExpr, hc *HandlerContext) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !hc.IsContextVar(sel.X) {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "Decode":
+		hc.SetRequestBody(call.Args[0])
+	case "Reply":
+		hc.AddResponse(hc.Status(call.Args[0]), call.Args[1])
+	default:
+		return false
+	}
+	return true
+}
+
+func kitRouteCall(call *ast.CallExpr) (RouteCall, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "On" || len(call.Args) != 3 {
+		return RouteCall{}, false
+	}
+	method, ok := stringLiteral(call.Args[0])
+	if !ok {
+		return RouteCall{}, false
+	}
+	path, ok := stringLiteral(call.Args[1])
+	if !ok {
+		return RouteCall{}, false
+	}
+	return RouteCall{Method: method, Path: path, Receiver: sel.X, Handler: call.Args[2]}, true
+}
+
+func TestRegisterFrameworkPlugsInRouter(t *testing.T) {
+	RegisterFramework(Framework{
+		Name:        "kit",
+		ImportPaths: []string{"example.com/kit"},
+		Routes:      RouteFinderFunc(kitRouteCall),
+		Handlers:    kitAnalyzer{},
+	})
+	t.Cleanup(func() { unregisterFramework("kit") })
+
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/widgets\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(root, "widgets.go"), `package widgets
+
+import "example.com/kit"
+
+type Widget struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+func Register(r *kit.Router) {
+	api := r.Group("/api")
+	api.On("POST", "/widgets", createWidget)
+}
+
+func createWidget(req *kit.Request) error {
+	var in Widget
+	req.Decode(&in)
+	return req.Reply(201, in)
+}
+`)
+
+	frameworks, err := selectFrameworks(nil, []string{root})
+	if err != nil {
+		t.Fatalf("selectFrameworks: %v", err)
+	}
+	if len(frameworks) != 1 || frameworks[0].Name != "kit" {
+		t.Fatalf("auto-detected frameworks = %v, want [kit]", frameworks)
+	}
+
+	spec, err := GenerateProjectOpenAPI(ProjectConfig{WorkspaceRoot: root})
+	if err != nil {
+		t.Fatalf("GenerateProjectOpenAPI: %v", err)
+	}
+	doc := string(spec)
+	for _, want := range []string{`"/api/widgets"`, `"201"`, `"requestBody"`, `"#/components/schemas/widgets_Widget"`} {
+		if !strings.Contains(doc, want) {
+			t.Fatalf("spec missing %s:\n%s", want, doc)
+		}
+	}
+}
+
+func TestSelectFrameworksByName(t *testing.T) {
+	frameworks, err := selectFrameworks([]string{"Gin", "echo"}, nil)
+	if err != nil {
+		t.Fatalf("selectFrameworks: %v", err)
+	}
+	if len(frameworks) != 2 || frameworks[0].Name != "gin" || frameworks[1].Name != "echo" {
+		t.Fatalf("selectFrameworks = %v", frameworks)
+	}
+	if _, err := selectFrameworks([]string{"martini"}, nil); err == nil {
+		t.Fatalf("expected unknown framework to be rejected")
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
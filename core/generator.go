@@ -17,6 +17,29 @@ type ProjectConfig struct {
 	OutputPath    string   // destination for GenerateAndSaveOpenAPI; relative paths resolved against WorkspaceRoot
 	ProjectName   string   // optional override for the generated document title/tagline
 	EnableAuthUI  bool     // include Bearer auth + global security requirement in generated OpenAPI doc
+	Frameworks    []string // registered framework names to scan for; empty auto-detects from the scanned imports
+	TypeCheck     bool     // resolve types with go/packages + go/types, falling back to AST heuristics if loading fails
+	EmbeddedAllOf bool     // compose embedded structs with allOf instead of flattening their fields
+
+	// SecurityMiddleware maps auth middleware, as written where it is attached
+	// ("jwtware.New", "keyauth.New", "requireAuth"), to the name of the
+	// security scheme it enforces. Operations behind mapped middleware get
+	// their own security requirement and EnableAuthUI no longer adds a global
+	// one.
+	SecurityMiddleware map[string]string
+	// SecuritySchemes declares security schemes by name, e.g. "ApiKeyAuth":
+	// {"type": "apiKey", "in": "header", "name": "X-API-Key"}. BearerAuth (JWT)
+	// and BasicAuth are predefined.
+	SecuritySchemes map[string]map[string]interface{}
+	// TypeMappings documents Go types by a schema fragment instead of a
+	// component, e.g. "money.Amount": {"type": "string", "format": "decimal"}.
+	// Keys are qualified by package name or import path and take precedence
+	// over the built-in mappings of types such as uuid.UUID and time.Duration.
+	TypeMappings map[string]map[string]interface{}
+
+	// OnDiagnostic, when set, receives the route-like calls that were skipped
+	// and other non-fatal problems encountered while generating.
+	OnDiagnostic func(Diagnostic)
 }
 
 // GenerateProjectOpenAPI discovers routes and handlers for the current project and returns
@@ -38,12 +61,28 @@ func GenerateProjectOpenAPI(configs ...ProjectConfig) ([]byte, error) {
 		return nil, err
 	}
 
-	routes, err := collectRoutes(routeInputs, cfg.SkipPrefixes)
+	frameworks, err := selectFrameworks(cfg.Frameworks, routeInputs)
 	if err != nil {
 		return nil, err
 	}
 
-	handlers, registry, err := BuildHandlerIndex(routes, root)
+	routes, diagnostics, err := collectRoutes(routeInputs, cfg.SkipPrefixes, frameworks)
+	if err != nil {
+		// The skipped candidates usually explain why no routes were found.
+		cfg.report(diagnostics)
+		return nil, err
+	}
+
+	registry := NewTypeRegistry()
+	if cfg.TypeCheck {
+		// A failed load leaves the registry in AST mode.
+		if err := registry.LoadPackages(root); err != nil {
+			diagnostics = append(diagnostics, Diagnostic{File: root, Message: "type checking disabled: " + err.Error()})
+		}
+	}
+	cfg.report(diagnostics)
+
+	handlers, registry, err := buildHandlerIndex(routes, root, registry)
 	if err != nil {
 		return nil, fmt.Errorf("core: build handler index: %w", err)
 	}
@@ -51,12 +90,48 @@ func GenerateProjectOpenAPI(configs ...ProjectConfig) ([]byte, error) {
 		return nil, fmt.Errorf("core: no handlers discovered under %s", root)
 	}
 
+	// The main package may carry swag's general API info (@title, @host,
+	// @securityDefinitions.*); ProjectName and SecuritySchemes win over it.
+	general := findGeneralInfo(append([]string{root}, routeInputs...))
 	projectName := strings.TrimSpace(cfg.ProjectName)
-	if projectName == "" {
+	if projectName == "" && (general == nil || general.title == "") {
 		projectName = deriveProjectName(root)
 	}
+	securitySchemes := cfg.SecuritySchemes
+	if general != nil && len(general.securitySchemes) > 0 {
+		securitySchemes = make(map[string]map[string]interface{}, len(general.securitySchemes)+len(cfg.SecuritySchemes))
+		for name, scheme := range general.securitySchemes {
+			securitySchemes[name] = scheme
+		}
+		for name, scheme := range cfg.SecuritySchemes {
+			securitySchemes[name] = scheme
+		}
+	}
 
-	return GenerateOpenAPI(routes, handlers, registry, projectName, cfg.EnableAuthUI)
+	spec, warnings, err := generateOpenAPI(routes, handlers, registry, openAPIOptions{
+		projectName:        projectName,
+		enableAuthUI:       cfg.EnableAuthUI,
+		securityMiddleware: cfg.SecurityMiddleware,
+		securitySchemes:    securitySchemes,
+		info:               general,
+		embeddedAllOf:      cfg.EmbeddedAllOf,
+		typeMappings:       cfg.TypeMappings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cfg.report(warnings)
+	return spec, nil
+}
+
+// report passes diagnostics to OnDiagnostic, if set.
+func (cfg ProjectConfig) report(diagnostics []Diagnostic) {
+	if cfg.OnDiagnostic == nil {
+		return
+	}
+	for _, d := range diagnostics {
+		cfg.OnDiagnostic(d)
+	}
 }
 
 // GenerateAndSaveOpenAPI builds the project OpenAPI document and writes it to disk.
@@ -151,19 +226,21 @@ func resolveRouteInputs(root string, inputs []string) ([]string, error) {
 	return paths, nil
 }
 
-func collectRoutes(paths []string, skipPrefixes []string) ([]RouteInfo, error) {
+func collectRoutes(paths []string, skipPrefixes []string, frameworks []Framework) ([]RouteInfo, []Diagnostic, error) {
 	if len(paths) == 0 {
-		return nil, fmt.Errorf("core: no input paths provided")
+		return nil, nil, fmt.Errorf("core: no input paths provided")
 	}
 
 	skipper := newRouteSkipper(skipPrefixes)
 	routeSet := make(map[string]RouteInfo)
+	var diagnostics []Diagnostic
 
 	for _, dir := range paths {
-		routes, err := FindRoutes(dir)
+		routes, found, err := findRoutes(dir, frameworks)
 		if err != nil {
-			return nil, fmt.Errorf("core: find routes in %s: %w", dir, err)
+			return nil, nil, fmt.Errorf("core: find routes in %s: %w", dir, err)
 		}
+		diagnostics = append(diagnostics, found...)
 		for _, r := range routes {
 			if skipper.Skip(r) {
 				continue
@@ -174,7 +251,7 @@ func collectRoutes(paths []string, skipPrefixes []string) ([]RouteInfo, error) {
 	}
 
 	if len(routeSet) == 0 {
-		return nil, fmt.Errorf("core: no routes discovered")
+		return nil, diagnostics, fmt.Errorf("core: no routes discovered")
 	}
 
 	result := make([]RouteInfo, 0, len(routeSet))
@@ -189,7 +266,7 @@ func collectRoutes(paths []string, skipPrefixes []string) ([]RouteInfo, error) {
 		return result[i].Path < result[j].Path
 	})
 
-	return result, nil
+	return result, diagnostics, nil
 }
 
 func deriveProjectName(root string) string {
@@ -218,7 +295,7 @@ type routeSkipper struct {
 }
 
 func newRouteSkipper(prefixes []string) routeSkipper {
-	defaults := []string{"/swagger", "/redoc"}
+	defaults := []string{"/swagger", "/redoc", "/scalar"}
 	var filtered []string
 	seen := make(map[string]struct{})
 	for _, p := range append(defaults, prefixes...) {
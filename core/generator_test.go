@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -15,41 +17,353 @@ func TestGenerateProjectOpenAPI_Fixtures(t *testing.T) {
 		"multipart",
 		"streaming",
 		"textresponse",
+		"servemux",
+		"chirouter",
+		"ginapp",
+		"echoapp",
+		"constpaths",
+		"inline",
+		"fibermodules",
+		"methodkeys",
+		"fiberparams",
+		"fiberheaders",
+		"splitserver",
+		"workspace/api",
+		"vendored",
+		"factories",
+		"swaggo",
+		"swaginfo",
+		"swaginfomain",
+		"validation",
+		"enums",
+		"embedded",
+		"doccomments",
+		"wellknown",
 	}
 
 	for _, name := range fixtures {
 		name := name
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			fixtureRoot := filepath.Join("testdata", "projects", name)
-			spec, err := GenerateProjectOpenAPI(ProjectConfig{WorkspaceRoot: fixtureRoot})
-			if err != nil {
-				routes, routeErr := FindRoutes(fixtureRoot)
-				t.Fatalf("GenerateProjectOpenAPI(%s) error = %v (routes=%d, first=%v, routeErr=%v)", name, err, len(routes), firstRouteDebug(routes), routeErr)
-			}
+			assertFixtureGolden(t, name, ProjectConfig{})
+		})
+	}
+}
 
-			actual := normalizeJSON(t, spec)
+func TestGenerateProjectOpenAPI_TypeCheckedFixtures(t *testing.T) {
+	fixtures := []string{
+		"typedpkgs",
+		"enums",
+		"embedded",
+		"wellknown",
+		"shadowing",
+	}
 
-			goldenPath := filepath.Join(fixtureRoot, "expected_openapi.json")
-			if update := os.Getenv("DOCLESS_UPDATE_GOLDEN"); update != "" {
-				if err := os.WriteFile(goldenPath, spec, 0o644); err != nil {
-					t.Fatalf("write golden: %v", err)
-				}
-			}
+	for _, name := range fixtures {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assertFixtureGolden(t, name, ProjectConfig{TypeCheck: true})
+		})
+	}
+}
+
+func TestGenerateProjectOpenAPI_SecurityFixture(t *testing.T) {
+	assertFixtureGolden(t, "security", ProjectConfig{
+		EnableAuthUI: true,
+		SecurityMiddleware: map[string]string{
+			"jwtware.New": "BearerAuth",
+			"keyauth.New": "ApiKeyAuth",
+			"requireAuth": "BearerAuth",
+		},
+		SecuritySchemes: map[string]map[string]interface{}{
+			"ApiKeyAuth": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+		},
+	})
+}
+
+func TestGenerateProjectOpenAPI_GinSecurityFixture(t *testing.T) {
+	assertFixtureGolden(t, "ginsecurity", ProjectConfig{
+		SecurityMiddleware: map[string]string{"requireAuth": "BearerAuth"},
+	})
+}
+
+func TestGenerateProjectOpenAPIOperationIDs(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/names\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(root, "routes.go"), `package names
+
+import "github.com/gofiber/fiber/v2"
+
+func Register(app *fiber.App) {
+	app.Get("/users/:id", getUser).Name("users.get")
+	app.Get("/me", getMe)
+	app.Get("/v1/ping", ping)
+	app.Get("/v2/ping", ping)
+}
+
+func getUser(c *fiber.Ctx) error { return c.SendStatus(204) }
+
+// @ID currentUser
+func getMe(c *fiber.Ctx) error { return c.SendStatus(204) }
 
-			goldenBytes, err := os.ReadFile(goldenPath)
+func ping(c *fiber.Ctx) error { return c.SendString("pong") }
+`)
+
+	var warnings []string
+	spec, err := GenerateProjectOpenAPI(ProjectConfig{
+		WorkspaceRoot: root,
+		OnDiagnostic:  func(d Diagnostic) { warnings = append(warnings, d.Message) },
+	})
+	if err != nil {
+		t.Fatalf("GenerateProjectOpenAPI: %v", err)
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		t.Fatalf("unmarshal spec: %v", err)
+	}
+	want := map[string]string{
+		"/users/{id}": "users.get",
+		"/me":         "currentUser",
+		"/v1/ping":    "names.ping_getV1Ping",
+		"/v2/ping":    "names.ping_getV2Ping",
+	}
+	for path, id := range want {
+		if got := doc.Paths[path]["get"].OperationID; got != id {
+			t.Fatalf("operationId of GET %s = %q, want %q", path, got, id)
+		}
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"names.ping" is used by GET /v1/ping, GET /v2/ping`) {
+		t.Fatalf("warnings = %q", warnings)
+	}
+}
+
+func TestGenerateProjectOpenAPIReportsSkippedRoutesOnFailure(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/cache\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(root, "cache.go"), `package cache
+
+func warm(store *Store) {
+	store.Get("/users", fill)
+}
+`)
+
+	var warnings []string
+	_, err := GenerateProjectOpenAPI(ProjectConfig{
+		WorkspaceRoot: root,
+		Frameworks:    []string{"fiber"},
+		OnDiagnostic:  func(d Diagnostic) { warnings = append(warnings, d.Message) },
+	})
+	if err == nil || !strings.Contains(err.Error(), "no routes discovered") {
+		t.Fatalf("err = %v, want no routes discovered", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "receiver store is not a known router") {
+		t.Fatalf("warnings = %q", warnings)
+	}
+}
+
+func TestGenerateProjectOpenAPIAnnotationWarnings(t *testing.T) {
+	var warnings []string
+	_, err := GenerateProjectOpenAPI(ProjectConfig{
+		WorkspaceRoot: filepath.Join("testdata", "projects", "swaggo"),
+		OnDiagnostic:  func(d Diagnostic) { warnings = append(warnings, d.Message) },
+	})
+	if err != nil {
+		t.Fatalf("GenerateProjectOpenAPI: %v", err)
+	}
+	want := []string{
+		"GET /orders/{id}: @Router of getOrder documents /v2/orders/{id}; using it",
+		`security scheme "ApiKeyAuth" is required but not declared`,
+		`security scheme "OAuth2" is required but not declared`,
+	}
+	if strings.Join(warnings, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("warnings = %q, want %q", warnings, want)
+	}
+}
+
+func TestGenerateProjectOpenAPIEmbeddedAllOf(t *testing.T) {
+	spec, err := GenerateProjectOpenAPI(ProjectConfig{
+		WorkspaceRoot: filepath.Join("testdata", "projects", "embedded"),
+		EmbeddedAllOf: true,
+	})
+	if err != nil {
+		t.Fatalf("GenerateProjectOpenAPI: %v", err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				AllOf []struct {
+					Ref        string                 `json:"$ref"`
+					Properties map[string]interface{} `json:"properties"`
+				} `json:"allOf"`
+				Properties map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	schemas := doc.Components.Schemas
+	var refs, own []string
+	for _, part := range schemas["models_UserResponse"].AllOf {
+		if part.Ref != "" {
+			refs = append(refs, part.Ref)
+		}
+		for name := range part.Properties {
+			own = append(own, name)
+		}
+	}
+	sort.Strings(own)
+	wantRefs := []string{
+		"#/components/schemas/models_BaseModel",
+		"#/components/schemas/models_Audit",
+		"#/components/schemas/models_meta",
+		"#/components/schemas/models_Named",
+		"#/components/schemas/models_Titled",
+	}
+	if strings.Join(refs, " ") != strings.Join(wantRefs, " ") {
+		t.Fatalf("allOf refs = %q, want %q", refs, wantRefs)
+	}
+	if want := "Labels email note owner"; strings.Join(own, " ") != want {
+		t.Fatalf("own properties = %q, want %q", own, want)
+	}
+	if base := schemas["models_BaseModel"].AllOf; len(base) != 2 || base[0].Ref != "#/components/schemas/shared_Timestamps" {
+		t.Fatalf("BaseModel allOf = %+v", base)
+	}
+	if _, ok := schemas["shared_Timestamps"].Properties["created_at"]; !ok {
+		t.Fatalf("shared_Timestamps = %+v", schemas["shared_Timestamps"])
+	}
+}
+
+func TestGenerateProjectOpenAPITypeMappings(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		typeCheck bool
+		key       string
+	}{
+		{name: "package name", key: "money.Amount"},
+		{name: "import path", key: "example.com/docoo/wellknown/money.Amount"},
+		{name: "type checked", typeCheck: true, key: "example.com/docoo/wellknown/money.Amount"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := GenerateProjectOpenAPI(ProjectConfig{
+				WorkspaceRoot: filepath.Join("testdata", "projects", "wellknown"),
+				TypeCheck:     tc.typeCheck,
+				TypeMappings: map[string]map[string]interface{}{
+					tc.key:          {"type": "string", "format": "decimal"},
+					"time.Duration": {"type": "string", "example": "1m30s"},
+				},
+			})
 			if err != nil {
-				t.Fatalf("read golden: %v", err)
+				t.Fatalf("GenerateProjectOpenAPI: %v", err)
 			}
-			expected := normalizeJSON(t, goldenBytes)
-
-			if !reflect.DeepEqual(expected, actual) {
-				t.Fatalf("OpenAPI mismatch.\nExpected: %s\nActual:   %s", mustMarshal(t, expected), mustMarshal(t, actual))
+			var doc struct {
+				Components struct {
+					Schemas map[string]struct {
+						Properties map[string]map[string]interface{} `json:"properties"`
+					} `json:"schemas"`
+				} `json:"components"`
+			}
+			if err := json.Unmarshal(spec, &doc); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if _, ok := doc.Components.Schemas["money_Amount"]; ok {
+				t.Fatalf("mapped type money.Amount got a component")
+			}
+			props := doc.Components.Schemas["jobs_Job"].Properties
+			if got := props["cost"]; got["type"] != "string" || got["format"] != "decimal" {
+				t.Fatalf("cost = %v", got)
+			}
+			if got := props["timeout"]; got["type"] != "string" || got["example"] != "1m30s" {
+				t.Fatalf("timeout = %v, want the configured mapping over the built-in one", got)
+			}
+			if got := props["worker"]; got["format"] != "ip" {
+				t.Fatalf("worker = %v", got)
 			}
 		})
 	}
 }
 
+func TestGenerateProjectOpenAPIModuleCacheTypes(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("GOMODCACHE", cache)
+	modDir := filepath.Join(cache, "github.com", "!our!org", "apitypes@v1.2.0")
+	if err := os.MkdirAll(modDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeTestFile(t, filepath.Join(modDir, "go.mod"), "module github.com/OurOrg/apitypes\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(modDir, "order.go"), `package apitypes
+
+type Order struct {
+	ID    string  `+"`json:\"id\"`"+`
+	Total float64 `+"`json:\"total\"`"+`
+}
+`)
+
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/shop\n\ngo 1.22\n\nrequire github.com/OurOrg/apitypes v1.2.0\n")
+	writeTestFile(t, filepath.Join(root, "routes.go"), `package shop
+
+import (
+	"github.com/OurOrg/apitypes"
+	"github.com/gofiber/fiber/v2"
+)
+
+func Register(app *fiber.App) {
+	app.Get("/orders/:id", getOrder)
+}
+
+func getOrder(c *fiber.Ctx) error {
+	var order apitypes.Order
+	return c.JSON(order)
+}
+`)
+
+	spec, err := GenerateProjectOpenAPI(ProjectConfig{WorkspaceRoot: root})
+	if err != nil {
+		t.Fatalf("GenerateProjectOpenAPI: %v", err)
+	}
+	if !strings.Contains(string(spec), `"total": {`) {
+		t.Fatalf("expected apitypes.Order to be resolved from the module cache:\n%s", spec)
+	}
+}
+
+// assertFixtureGolden generates the spec for testdata/projects/<name> and compares
+// it with expected_openapi.json, rewriting the golden when DOCLESS_UPDATE_GOLDEN is set.
+func assertFixtureGolden(t *testing.T, name string, cfg ProjectConfig) {
+	t.Helper()
+	fixtureRoot := filepath.Join("testdata", "projects", name)
+	cfg.WorkspaceRoot = fixtureRoot
+	spec, err := GenerateProjectOpenAPI(cfg)
+	if err != nil {
+		routes, routeErr := FindRoutes(fixtureRoot)
+		t.Fatalf("GenerateProjectOpenAPI(%s) error = %v (routes=%d, first=%v, routeErr=%v)", name, err, len(routes), firstRouteDebug(routes), routeErr)
+	}
+
+	actual := normalizeJSON(t, spec)
+
+	goldenPath := filepath.Join(fixtureRoot, "expected_openapi.json")
+	if update := os.Getenv("DOCLESS_UPDATE_GOLDEN"); update != "" {
+		if err := os.WriteFile(goldenPath, spec, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+
+	goldenBytes, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	expected := normalizeJSON(t, goldenBytes)
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("OpenAPI mismatch.\nExpected: %s\nActual:   %s", mustMarshal(t, expected), mustMarshal(t, actual))
+	}
+}
+
 func normalizeJSON(t *testing.T, data []byte) interface{} {
 	t.Helper()
 	var v interface{}
@@ -79,3 +393,17 @@ func firstRouteDebug(routes []RouteInfo) interface{} {
 		"handler":   r.HandlerName,
 	}
 }
+
+func TestParseGeneralInfoKeepsScopeCase(t *testing.T) {
+	var info generalInfo
+	parseGeneralInfoGroup(&info, []string{
+		"@securityDefinitions.oauth2.application OAuth2",
+		"@tokenUrl https://auth.example.com/token",
+		"@Scope.readUsers Read users",
+	})
+	flow := info.securitySchemes["OAuth2"]["flows"].(map[string]interface{})["clientCredentials"].(map[string]interface{})
+	scopes := flow["scopes"].(map[string]interface{})
+	if len(scopes) != 1 || scopes["readUsers"] != "Read users" {
+		t.Fatalf("scopes = %v, want readUsers", scopes)
+	}
+}
@@ -27,6 +27,7 @@ type HandlerInfo struct {
 	Description      string
 	Notes            []string
 	Tags             []string
+	OperationID      string // set with @ID
 	Consumes         []string
 	Produces         []string
 	InputType        string
@@ -41,7 +42,18 @@ type HandlerInfo struct {
 	queryParamHints  map[string]*queryParamHint
 	EmptyBodyStatus  map[string]bool
 	ctxVars          map[string]struct{}
+	requestVars      map[string]struct{}
+	framework        string
+	annotated        map[string]struct{} // statuses documented with @Success/@Failure, which win over inferred ones
 	NoAuth           bool
+	Deprecated       bool                        // set with @Deprecated
+	Security         []map[string][]string       // @Security requirements, one per alternative
+	Routers          []RouterAnnotation          // @Router lines, checked against the discovered routes
+	ResponseHeaders  map[string][]ResponseHeader // HTTP status or "all" -> @Header entries
+	// ResponseDescriptions holds the descriptions given with @Success and
+	// @Failure, by HTTP status.
+	ResponseDescriptions map[string]string
+	responseMedia        map[string]string // media types of inferred XML, YAML and protobuf responses, by HTTP status
 }
 
 // Parameter captures non-body inputs declared via annotations.
@@ -51,10 +63,23 @@ type Parameter struct {
 	Type        string
 	Required    bool
 	Description string
+	Format      string   // schema format, e.g. uuid or date
+	Pattern     string   // regular expression the value must match
+	Minimum     *float64 // inclusive numeric bounds
+	Maximum     *float64
+	MinLength   *int // string length bounds
+	MaxLength   *int
+	Enum        []string // allowed values, from Enums(...)
+	Default     string   // default value as written, empty when unset
+	Example     string   // example value as written, empty when unset
 }
 
 // BuildHandlerIndex groups routes by file and extracts handler metadata.
 func BuildHandlerIndex(routes []RouteInfo, workspaceRoot string) (map[string]HandlerInfo, *TypeRegistry, error) {
+	return buildHandlerIndex(routes, workspaceRoot, NewTypeRegistry())
+}
+
+func buildHandlerIndex(routes []RouteInfo, workspaceRoot string, registry *TypeRegistry) (map[string]HandlerInfo, *TypeRegistry, error) {
 	local := make(map[string][]RouteInfo)
 	external := make(map[string][]RouteInfo)
 	for _, r := range routes {
@@ -69,7 +94,6 @@ func BuildHandlerIndex(routes []RouteInfo, workspaceRoot string) (map[string]Han
 	}
 
 	result := make(map[string]HandlerInfo)
-	registry := NewTypeRegistry()
 	if workspaceRoot != "" {
 		if err := registry.IndexWorkspace(workspaceRoot); err != nil {
 			return nil, nil, err
@@ -90,15 +114,16 @@ func BuildHandlerIndex(routes []RouteInfo, workspaceRoot string) (map[string]Han
 		return result, registry, nil
 	}
 
-	modulePath, err := modulePathFromRoot(workspaceRoot)
-	if err != nil {
-		return nil, nil, err
-	}
-
+	modules := loadModuleSet(workspaceRoot)
 	for importPath, items := range external {
-		dir, err := resolveImportDir(workspaceRoot, modulePath, importPath)
-		if err != nil {
-			return nil, nil, err
+		dir, ok := modules.importDir(importPath)
+		if !ok && allFactories(items) {
+			// Factories of packages outside the workspace, such as
+			// promhttp.Handler(), are left undocumented.
+			continue
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("core: import %s is not in a module of the workspace", importPath)
 		}
 		infos, err := analyzeHandlersInPackage(importPath, dir, items, registry)
 		if err != nil {
@@ -113,14 +138,21 @@ func BuildHandlerIndex(routes []RouteInfo, workspaceRoot string) (map[string]Han
 }
 
 func analyzeHandlersInFile(filePath string, routes []RouteInfo, registry *TypeRegistry) (map[string]HandlerInfo, error) {
-	fset := token.NewFileSet()
-	// Parse comments to leverage swagger-like annotations.
-	node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
-	if err != nil {
-		return nil, err
+	// In type-checked mode the go/packages syntax must be used so that
+	// expression lookups hit; otherwise parse comments to leverage
+	// swagger-like annotations.
+	node, fset := registry.syntaxFor(filePath)
+	if node == nil {
+		var err error
+		fset = token.NewFileSet()
+		node, err = parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if registry != nil {
+		registry.addEnums(filePath, node)
 		for _, decl := range node.Decls {
 			gen, ok := decl.(*ast.GenDecl)
 			if !ok || gen.Tok != token.TYPE {
@@ -132,6 +164,7 @@ func analyzeHandlersInFile(filePath string, routes []RouteInfo, registry *TypeRe
 					continue
 				}
 				registry.Add(node.Name.Name, filePath, ts)
+				registry.recordTypeDoc(gen, ts)
 			}
 		}
 	}
@@ -156,35 +189,188 @@ func analyzeHandlersInFile(filePath string, routes []RouteInfo, registry *TypeRe
 		if !ok {
 			continue
 		}
+		fn, doc := handlerDecl(fn, route, node, fset)
+		handlerInfos[route.HandlerID] = analyzeHandlerFunc(fn, doc, route, node.Name.Name, filePath, registry)
+	}
 
-		info := HandlerInfo{
-			ID:              route.HandlerID,
-			Name:            fn.Name.Name,
-			Package:         node.Name.Name,
-			File:            filePath,
-			Receiver:        extractReceiverType(fn),
-			Responses:       make(map[string]string),
-			ResponseSchemas: make(map[string]Schema),
-			queryParamHints: make(map[string]*queryParamHint),
-			EmptyBodyStatus: make(map[string]bool),
-			ctxVars:         collectCtxParams(fn),
+	// Inline handlers are func literals passed to the registration call; their
+	// annotations live in the comment directly above that call.
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
 		}
+		for _, arg := range call.Args {
+			lit, ok := arg.(*ast.FuncLit)
+			if !ok {
+				continue
+			}
+			route, ok := needed[inlineHandlerID(filePath, fset.Position(lit.Pos()).Line)]
+			if !ok {
+				continue
+			}
+			fn := &ast.FuncDecl{Name: ast.NewIdent(route.HandlerName), Type: lit.Type, Body: lit.Body}
+			doc := commentAbove(node, fset, call.Pos())
+			handlerInfos[route.HandlerID] = analyzeHandlerFunc(fn, doc, route, node.Name.Name, filePath, registry)
+		}
+		return true
+	})
+
+	return handlerInfos, nil
+}
 
-		populateFromDoc(fn.Doc, &info)
-		populateFromBody(fn.Body, &info, registry)
-		ensurePathParameters(&info, route)
+// analyzeHandlerFunc builds the metadata of fn serving route. doc holds the
+// handler's annotations.
+func analyzeHandlerFunc(fn *ast.FuncDecl, doc *ast.CommentGroup, route RouteInfo, pkg, file string, registry *TypeRegistry) HandlerInfo {
+	info := HandlerInfo{
+		ID:              route.HandlerID,
+		Name:            fn.Name.Name,
+		Package:         pkg,
+		File:            file,
+		Receiver:        extractReceiverType(fn),
+		Responses:       make(map[string]string),
+		ResponseSchemas: make(map[string]Schema),
+		queryParamHints: make(map[string]*queryParamHint),
+		EmptyBodyStatus: make(map[string]bool),
+		ctxVars:         collectCtxParams(fn),
+		requestVars:     collectHTTPRequestParams(fn),
+		framework:       handlerFramework(fn),
+	}
+
+	populateFromDoc(doc, &info)
+	populateFromBody(fn.Body, &info, registry)
+	ensurePathParameters(&info, route)
 
-		// Ensure OutputType mirrors the default success response if defined.
-		if info.OutputType == "" {
-			if success, ok := info.Responses["200"]; ok {
-				info.OutputType = success
+	// Ensure OutputType mirrors the default success response if defined.
+	if info.OutputType == "" {
+		if success, ok := info.Responses["200"]; ok {
+			info.OutputType = success
+		}
+	}
+	return info
+}
+
+// handlerDecl returns the function to analyse for route and its doc comment.
+// For a handler factory this is the closure fn returns, documented by fn's doc
+// comment followed by the comment above the return statement.
+func handlerDecl(fn *ast.FuncDecl, route RouteInfo, file *ast.File, fset *token.FileSet) (*ast.FuncDecl, *ast.CommentGroup) {
+	if !route.HandlerFactory {
+		return fn, fn.Doc
+	}
+	lit, ret := factoryClosure(fn)
+	if lit == nil {
+		return fn, fn.Doc
+	}
+	var comments []*ast.Comment
+	if fn.Doc != nil {
+		comments = append(comments, fn.Doc.List...)
+	}
+	if above := commentAbove(file, fset, ret.Pos()); above != nil {
+		comments = append(comments, above.List...)
+	}
+	var doc *ast.CommentGroup
+	if len(comments) > 0 {
+		doc = &ast.CommentGroup{List: comments}
+	}
+	closure := &ast.FuncDecl{Recv: fn.Recv, Name: fn.Name, Type: lit.Type, Body: lit.Body}
+	return closure, doc
+}
+
+// factoryClosure finds the handler closure returned by the factory fn, either
+// directly (return func(c *fiber.Ctx) error {...}), through a local variable or
+// wrapped in an adapter such as http.HandlerFunc(func(w, r) {...}). Returns of
+// nested closures are not considered.
+func factoryClosure(fn *ast.FuncDecl) (*ast.FuncLit, *ast.ReturnStmt) {
+	if fn.Body == nil || fn.Type.Results == nil {
+		return nil, nil
+	}
+	closures := make(map[string]*ast.FuncLit)
+	var (
+		found *ast.FuncLit
+		ret   *ast.ReturnStmt
+	)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch v := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			for i, rhs := range v.Rhs {
+				ident, ok := v.Lhs[i].(*ast.Ident)
+				if lit := closureExpr(rhs); ok && lit != nil && len(v.Lhs) == len(v.Rhs) {
+					closures[ident.Name] = lit
+				}
+			}
+		case *ast.ValueSpec:
+			for i, value := range v.Values {
+				if lit := closureExpr(value); lit != nil && len(v.Names) == len(v.Values) {
+					closures[v.Names[i].Name] = lit
+				}
+			}
+		case *ast.ReturnStmt:
+			if len(v.Results) != 1 {
+				return false
+			}
+			lit := closureExpr(v.Results[0])
+			if ident, ok := v.Results[0].(*ast.Ident); ok {
+				lit = closures[ident.Name]
+			}
+			if lit != nil {
+				found, ret = lit, v
 			}
+			return false
 		}
+		return true
+	})
+	if found == nil {
+		return nil, nil
+	}
+	decl := &ast.FuncDecl{Type: found.Type}
+	if handlerFramework(decl) == "" && len(collectHTTPRequestParams(decl)) == 0 {
+		return nil, nil
+	}
+	return found, ret
+}
 
-		handlerInfos[route.HandlerID] = info
+// closureExpr returns the func literal expr evaluates to, looking through
+// single-argument adapter conversions.
+func closureExpr(expr ast.Expr) *ast.FuncLit {
+	switch v := expr.(type) {
+	case *ast.FuncLit:
+		return v
+	case *ast.ParenExpr:
+		return closureExpr(v.X)
+	case *ast.CallExpr:
+		if len(v.Args) == 1 {
+			if lit, ok := v.Args[0].(*ast.FuncLit); ok {
+				return lit
+			}
+		}
 	}
+	return nil
+}
 
-	return handlerInfos, nil
+// allFactories reports whether every route is served by a handler factory.
+func allFactories(routes []RouteInfo) bool {
+	for _, r := range routes {
+		if !r.HandlerFactory {
+			return false
+		}
+	}
+	return len(routes) > 0
+}
+
+// commentAbove returns the comment group ending on the line before pos.
+func commentAbove(file *ast.File, fset *token.FileSet, pos token.Pos) *ast.CommentGroup {
+	line := fset.Position(pos).Line
+	for _, group := range file.Comments {
+		if fset.Position(group.End()).Line == line-1 {
+			return group
+		}
+	}
+	return nil
 }
 
 func analyzeHandlersInPackage(importPath, dir string, routes []RouteInfo, registry *TypeRegistry) (map[string]HandlerInfo, error) {
@@ -214,7 +400,12 @@ func analyzeHandlersInPackage(importPath, dir string, routes []RouteInfo, regist
 
 	for _, pkg := range pkgs {
 		for filePath, node := range pkg.Files {
+			fileSet := fset
+			if typed, typedFset := registry.syntaxFor(filePath); typed != nil {
+				node, fileSet = typed, typedFset
+			}
 			if registry != nil {
+				registry.addEnums(filePath, node)
 				for _, decl := range node.Decls {
 					gen, ok := decl.(*ast.GenDecl)
 					if !ok || gen.Tok != token.TYPE {
@@ -226,6 +417,7 @@ func analyzeHandlersInPackage(importPath, dir string, routes []RouteInfo, regist
 							continue
 						}
 						registry.Add(pkg.Name, filePath, ts)
+						registry.recordTypeDoc(gen, ts)
 					}
 				}
 			}
@@ -239,31 +431,8 @@ func analyzeHandlersInPackage(importPath, dir string, routes []RouteInfo, regist
 				if !ok {
 					continue
 				}
-
-				info := HandlerInfo{
-					ID:              route.HandlerID,
-					Name:            fn.Name.Name,
-					Package:         pkg.Name,
-					File:            filePath,
-					Receiver:        extractReceiverType(fn),
-					Responses:       make(map[string]string),
-					ResponseSchemas: make(map[string]Schema),
-					queryParamHints: make(map[string]*queryParamHint),
-					EmptyBodyStatus: make(map[string]bool),
-					ctxVars:         collectCtxParams(fn),
-				}
-
-				populateFromDoc(fn.Doc, &info)
-				populateFromBody(fn.Body, &info, registry)
-				ensurePathParameters(&info, route)
-
-				if info.OutputType == "" {
-					if success, ok := info.Responses["200"]; ok {
-						info.OutputType = success
-					}
-				}
-
-				handlerInfos[route.HandlerID] = info
+				fn, doc := handlerDecl(fn, route, node, fileSet)
+				handlerInfos[route.HandlerID] = analyzeHandlerFunc(fn, doc, route, pkg.Name, filePath, registry)
 			}
 		}
 	}
@@ -294,7 +463,7 @@ func ensurePathParameters(info *HandlerInfo, route RouteInfo) {
 	if info == nil {
 		return
 	}
-	params := extractPathParams(route.Path)
+	params := routePathParams(route.Path)
 	if len(params) == 0 {
 		return
 	}
@@ -304,61 +473,23 @@ func ensurePathParameters(info *HandlerInfo, route RouteInfo) {
 			existing[strings.ToLower(p.Name)] = struct{}{}
 		}
 	}
-	for _, name := range params {
-		key := strings.ToLower(name)
+	for _, param := range params {
+		key := strings.ToLower(param.Name)
 		if _, ok := existing[key]; ok {
 			continue
 		}
-		info.Params = append(info.Params, Parameter{
-			Name:     name,
-			In:       "path",
-			Required: true,
-			Type:     "string",
-		})
+		info.Params = append(info.Params, param)
 		existing[key] = struct{}{}
 	}
 }
 
 func extractPathParams(routePath string) []string {
-	routePath = strings.TrimSpace(routePath)
-	if routePath == "" {
-		return nil
-	}
-	segments := strings.Split(routePath, "/")
-	var params []string
-	seen := make(map[string]struct{})
-	for _, segment := range segments {
-		segment = strings.TrimSpace(segment)
-		if segment == "" {
-			continue
-		}
-		if strings.HasPrefix(segment, ":") {
-			name := strings.TrimPrefix(segment, ":")
-			name = strings.TrimSpace(name)
-			if name == "" {
-				continue
-			}
-			if _, ok := seen[strings.ToLower(name)]; ok {
-				continue
-			}
-			seen[strings.ToLower(name)] = struct{}{}
-			params = append(params, name)
-			continue
-		}
-		if strings.HasPrefix(segment, "*") {
-			name := strings.TrimPrefix(segment, "*")
-			name = strings.TrimSpace(name)
-			if name == "" {
-				name = "wildcard"
-			}
-			if _, ok := seen[strings.ToLower(name)]; ok {
-				continue
-			}
-			seen[strings.ToLower(name)] = struct{}{}
-			params = append(params, name)
-		}
+	params := routePathParams(routePath)
+	names := make([]string, 0, len(params))
+	for _, p := range params {
+		names = append(names, p.Name)
 	}
-	return params
+	return names
 }
 
 func modulePathFromRoot(root string) (string, error) {
@@ -384,26 +515,6 @@ func modulePathFromRoot(root string) (string, error) {
 	return "", fmt.Errorf("core: module path not found in go.mod")
 }
 
-func resolveImportDir(root, modulePath, importPath string) (string, error) {
-	importPath = strings.TrimSpace(importPath)
-	if importPath == "" {
-		return "", fmt.Errorf("core: empty import path")
-	}
-	modulePath = strings.TrimSpace(modulePath)
-	if modulePath == "" {
-		return "", fmt.Errorf("core: module path unknown")
-	}
-	if !strings.HasPrefix(importPath, modulePath) {
-		return "", fmt.Errorf("core: import %s outside module %s", importPath, modulePath)
-	}
-	rel := strings.TrimPrefix(importPath, modulePath)
-	rel = strings.TrimPrefix(rel, "/")
-	if rel == "" {
-		return root, nil
-	}
-	return filepath.Join(root, filepath.FromSlash(rel)), nil
-}
-
 func populateFromDoc(doc *ast.CommentGroup, info *HandlerInfo) {
 	if doc == nil {
 		return
@@ -417,6 +528,9 @@ func populateFromDoc(doc *ast.CommentGroup, info *HandlerInfo) {
 			continue
 		}
 
+		if isGodocMarker(line) {
+			continue
+		}
 		if !strings.HasPrefix(line, "@") {
 			// Treat plain comment lines as description if no explicit description was found.
 			if info.Description == "" {
@@ -438,6 +552,8 @@ func populateFromDoc(doc *ast.CommentGroup, info *HandlerInfo) {
 		switch tag {
 		case "@Summary":
 			info.Summary = strings.TrimSpace(rest)
+		case "@ID":
+			info.OperationID = strings.TrimSpace(rest)
 		case "@Description":
 			info.Description = strings.TrimSpace(rest)
 		case "@Tags":
@@ -450,7 +566,15 @@ func populateFromDoc(doc *ast.CommentGroup, info *HandlerInfo) {
 		case "@Param":
 			parseParamAnnotation(rest, info)
 		case "@Success", "@Failure":
-			parseResponseAnnotation(fields, info)
+			parseResponseAnnotation(rest, info)
+		case "@Header":
+			parseHeaderAnnotation(rest, info)
+		case "@Router":
+			parseRouterAnnotation(rest, info)
+		case "@Security":
+			parseSecurityAnnotation(rest, info)
+		case "@Deprecated":
+			info.Deprecated = true
 		case "@NoAuth":
 			info.NoAuth = true
 		}
@@ -475,8 +599,29 @@ func populateFromBody(body *ast.BlockStmt, info *HandlerInfo, registry *TypeRegi
 	}
 
 	queryBindings := make(map[string]string)
+	state := newHandlerState()
+	analyzer := handlerAnalyzerFor(info.framework)
+	hc := &HandlerContext{Info: info, Registry: registry, varTypes: varTypes, state: state}
+
+	// In type-checked mode go/types answers take precedence over the heuristics
+	// below. They are applied per node, so a name always carries the type of the
+	// variable it denotes there, even when an inner scope shadows it.
+	typedVars := registry.typedVarTypes(body)
+	applyTypedVars := func(n ast.Node) {
+		if len(typedVars) == 0 {
+			return
+		}
+		ast.Inspect(n, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				if typ, ok := typedVars[ident]; ok {
+					varTypes[ident.Name] = typ
+				}
+			}
+			return true
+		})
+	}
 
-	ast.Inspect(body, func(n ast.Node) bool {
+	visit := func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.DeclStmt:
 			decl, ok := node.Decl.(*ast.GenDecl)
@@ -505,6 +650,8 @@ func populateFromBody(body *ast.BlockStmt, info *HandlerInfo, registry *TypeRegi
 				}
 			}
 		case *ast.AssignStmt:
+			trackHTTPQueryVars(node, info, state)
+			trackFiberHeaderVars(node, info, state)
 			if node.Tok != token.DEFINE {
 				// track direct assignments (e.g. t = append(...))
 				handleAssignmentForQuery(node, info, varTypes, queryBindings)
@@ -558,94 +705,46 @@ func populateFromBody(body *ast.BlockStmt, info *HandlerInfo, registry *TypeRegi
 			}
 			handleAssignmentForQuery(node, info, varTypes, queryBindings)
 		case *ast.CallExpr:
-			sel, ok := node.Fun.(*ast.SelectorExpr)
-			if !ok || sel.Sel == nil {
+			if analyzer.AnalyzeCall(node, hc) {
 				return true
 			}
-			switch sel.Sel.Name {
-			case "BodyParser":
-				if info.InputType != "" || len(node.Args) == 0 {
-					return true
-				}
-				if unary, ok := node.Args[0].(*ast.UnaryExpr); ok && unary.Op == token.AND {
-					if ident, ok := unary.X.(*ast.Ident); ok {
-						if typ, ok := varTypes[ident.Name]; ok {
-							info.InputType = typ
-						}
-					}
-				}
-			case "FormFile":
-				if len(node.Args) == 0 {
-					return true
-				}
-				if lit, ok := node.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-					if name, err := strconv.Unquote(lit.Value); err == nil {
-						ensureFormParam(info, Parameter{
-							Name:     name,
-							In:       "formData",
-							Type:     "file",
-							Required: true,
-						})
-					}
-				}
-			case "FormValue":
-				if len(node.Args) == 0 {
-					return true
-				}
-				if lit, ok := node.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-					if name, err := strconv.Unquote(lit.Value); err == nil {
-						ensureFormParam(info, Parameter{
-							Name:        name,
-							In:          "formData",
-							Type:        "string",
-							Required:    false,
-							Description: "",
-						})
-					}
-				}
-			case "Query":
-				if len(node.Args) == 0 {
-					return true
-				}
-				if lit, ok := node.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-					if name, err := strconv.Unquote(lit.Value); err == nil {
-						ensureQueryParam(info, name, false)
-					}
-				}
-			case "QueryInt", "QueryBool", "QueryFloat":
-				if len(node.Args) == 0 {
-					return true
-				}
-				if lit, ok := node.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-					if name, err := strconv.Unquote(lit.Value); err == nil {
-						ensureQueryParam(info, name, false)
-					}
-				}
-			case "QueryParser":
-				if len(node.Args) == 0 {
-					return true
-				}
-				typeName := strings.TrimSpace(inferTypeFromExpr(node.Args[0], registry))
-				if direct := strings.TrimSpace(varTypes[typeName]); direct != "" {
-					typeName = direct
-				}
-				if typeName == "" {
-					if unary, ok := node.Args[0].(*ast.UnaryExpr); ok && unary.Op == token.AND {
-						if ident, ok := unary.X.(*ast.Ident); ok {
-							typeName = varTypes[ident.Name]
-						}
-					}
-				}
-				ensureQueryStructParams(info, typeName, registry)
-			}
 			if processCtxResponseCall(node, info, varTypes, registry) {
 				return true
 			}
+		case *ast.IndexExpr:
+			processFiberHeaderIndex(node, info, state)
 		case *ast.ReturnStmt:
 			handleReturnResponses(node, info, varTypes, registry)
 		}
 		return true
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.DeclStmt, *ast.AssignStmt, *ast.CallExpr, *ast.IndexExpr, *ast.ReturnStmt:
+			applyTypedVars(n)
+		}
+		cont := visit(n)
+		switch n.(type) {
+		case *ast.DeclStmt, *ast.AssignStmt:
+			applyTypedVars(n)
+		}
+		return cont
 	})
+	state.finish(info)
+}
+
+// setInputTypeFromArg records the request body type from a decode target such as &req.
+func setInputTypeFromArg(info *HandlerInfo, arg ast.Expr, varTypes map[string]string) {
+	if info == nil || info.InputType != "" {
+		return
+	}
+	if unary, ok := arg.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		if ident, ok := unary.X.(*ast.Ident); ok {
+			if typ, ok := varTypes[ident.Name]; ok {
+				info.InputType = typ
+			}
+		}
+	}
 }
 
 func handleReturnResponses(ret *ast.ReturnStmt, info *HandlerInfo, varTypes map[string]string, registry *TypeRegistry) {
@@ -848,6 +947,20 @@ func ensureBinaryResponse(info *HandlerInfo, status string) {
 	}
 }
 
+// ensureEncodedResponse documents a body encoded before it is written, as by
+// echo's c.JSONBlob(code, b), whose schema cannot be told.
+func ensureEncodedResponse(info *HandlerInfo, status string) {
+	if info == nil || status == "" {
+		return
+	}
+	if info.ResponseSchemas == nil {
+		info.ResponseSchemas = make(map[string]Schema)
+	}
+	if _, exists := info.ResponseSchemas[status]; !exists {
+		info.ResponseSchemas[status] = Schema{}
+	}
+}
+
 func ensureTextResponse(info *HandlerInfo, status string) {
 	if info == nil || status == "" {
 		return
@@ -862,6 +975,20 @@ func ensureTextResponse(info *HandlerInfo, status string) {
 	}
 }
 
+// setResponseMedia records the media type of the inferred response for
+// status; the first one written wins.
+func setResponseMedia(info *HandlerInfo, status, media string) {
+	if info == nil || status == "" || media == "" {
+		return
+	}
+	if info.responseMedia == nil {
+		info.responseMedia = make(map[string]string)
+	}
+	if _, exists := info.responseMedia[status]; !exists {
+		info.responseMedia[status] = media
+	}
+}
+
 func addResponseFromExpr(info *HandlerInfo, status string, expr ast.Expr, varTypes map[string]string, registry *TypeRegistry) {
 	if info == nil || expr == nil || status == "" {
 		return
@@ -959,6 +1086,110 @@ func ensureQueryParam(info *HandlerInfo, name string, allowMultiple bool) {
 	})
 }
 
+// ensureRequestParam records an optional header or cookie parameter unless one
+// of that name is already documented. Header names compare case-insensitively;
+// Accept, Content-Type and Authorization are described elsewhere in OpenAPI and
+// are not parameters.
+func ensureRequestParam(info *HandlerInfo, in, name, typ string) {
+	name = strings.TrimSpace(name)
+	if info == nil || name == "" {
+		return
+	}
+	if in == "header" {
+		switch strings.ToLower(name) {
+		case "accept", "content-type", "authorization":
+			return
+		}
+	}
+	for _, p := range info.Params {
+		if !strings.EqualFold(p.In, in) {
+			continue
+		}
+		if p.Name == name || (in == "header" && strings.EqualFold(p.Name, name)) {
+			return
+		}
+	}
+	if typ == "" {
+		typ = "string"
+	}
+	info.Params = append(info.Params, Parameter{Name: name, In: in, Type: typ})
+}
+
+// ensureTypedQueryParam records a query parameter whose Go type is known, as
+// for c.QueryInt("limit", 20) or a QueryParser field. def is the literal
+// fallback value, empty when there is none. Annotated types are kept.
+func ensureTypedQueryParam(info *HandlerInfo, name, typ, def string) {
+	if info == nil {
+		return
+	}
+	ensureQueryParam(info, name, strings.HasPrefix(typ, "[]"))
+	for i := range info.Params {
+		p := &info.Params[i]
+		if !strings.EqualFold(p.In, "query") || !strings.EqualFold(p.Name, strings.TrimSpace(name)) {
+			continue
+		}
+		if typ != "" && (p.Type == "string" || p.Type == "[]string") {
+			p.Type = typ
+		}
+		if p.Default == "" {
+			p.Default = def
+		}
+		return
+	}
+}
+
+// ensurePathParam types the path parameter name, as read by c.ParamsInt or a
+// ParamsParser field. Route constraints and annotations take precedence.
+func ensurePathParam(info *HandlerInfo, name, typ string) {
+	name = strings.TrimSpace(name)
+	if info == nil || name == "" {
+		return
+	}
+	if typ == "" {
+		typ = "string"
+	}
+	for i := range info.Params {
+		p := &info.Params[i]
+		if strings.EqualFold(p.In, "path") && strings.EqualFold(p.Name, name) {
+			if p.Type == "" || p.Type == "string" {
+				p.Type = typ
+			}
+			return
+		}
+	}
+	info.Params = append(info.Params, Parameter{Name: name, In: "path", Type: typ, Required: true})
+}
+
+// handlerState is what a handler body walk carries from one call to the next:
+// a status set apart from the body (net/http's w.WriteHeader, gin's c.Status)
+// and the variables holding r.URL.Query() or fiber's c.GetReqHeaders().
+type handlerState struct {
+	status     string              // status set by the last WriteHeader or Status call
+	written    bool                // whether a body was written after that status
+	queryVars  map[string]struct{} // variables holding r.URL.Query()
+	headerVars map[string]struct{} // variables holding fiber's c.GetReqHeaders()
+}
+
+func newHandlerState() *handlerState {
+	return &handlerState{queryVars: make(map[string]struct{}), headerVars: make(map[string]struct{})}
+}
+
+// finish records a status that was never followed by a body.
+func (s *handlerState) finish(info *HandlerInfo) {
+	if s == nil || s.status == "" || s.written {
+		return
+	}
+	ensureEmptyResponse(info, s.status)
+}
+
+// pending returns the status a body written now is sent with.
+func (s *handlerState) pending() string {
+	if s.status == "" {
+		return "200"
+	}
+	return s.status
+}
+
 type ctxResponseKind int
 
 const (
@@ -967,8 +1198,21 @@ const (
 	ctxResponseEmpty
 	ctxResponseBinary
 	ctxResponseText
+	ctxResponseXML
+	ctxResponseYAML
+	ctxResponseProtoBuf
+	ctxResponseHTML
 )
 
+// ctxResponseMedia holds the media types of the response kinds documented
+// apart from the handler's Produces.
+var ctxResponseMedia = map[ctxResponseKind]string{
+	ctxResponseXML:      "application/xml",
+	ctxResponseYAML:     "application/x-yaml",
+	ctxResponseProtoBuf: "application/x-protobuf",
+	ctxResponseHTML:     "text/html",
+}
+
 func processCtxResponseCall(call *ast.CallExpr, info *HandlerInfo, varTypes map[string]string, registry *TypeRegistry) bool {
 	if call == nil || info == nil {
 		return false
@@ -981,11 +1225,16 @@ func processCtxResponseCall(call *ast.CallExpr, info *HandlerInfo, varTypes map[
 		status = "200"
 	}
 	switch kind {
-	case ctxResponseJSON:
-		if body == nil {
-			return false
+	case ctxResponseJSON, ctxResponseXML, ctxResponseYAML, ctxResponseProtoBuf:
+		if body != nil {
+			addResponseFromExpr(info, status, body, varTypes, registry)
+		} else {
+			ensureEncodedResponse(info, status)
 		}
-		addResponseFromExpr(info, status, body, varTypes, registry)
+		setResponseMedia(info, status, ctxResponseMedia[kind])
+	case ctxResponseHTML:
+		ensureTextResponse(info, status)
+		setResponseMedia(info, status, ctxResponseMedia[kind])
 	case ctxResponseEmpty:
 		ensureEmptyResponse(info, status)
 	case ctxResponseBinary:
@@ -1001,42 +1250,10 @@ func processCtxResponseCall(call *ast.CallExpr, info *HandlerInfo, varTypes map[
 }
 
 func classifyCtxResponseCall(call *ast.CallExpr, info *HandlerInfo) (ctxResponseKind, string, ast.Expr) {
-	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok || sel.Sel == nil {
-		return ctxResponseUnknown, "", nil
-	}
-	method := sel.Sel.Name
-	status, ok := unwrapCtxReceiver(sel.X, info)
-	if !ok {
-		return ctxResponseUnknown, "", nil
-	}
-	switch method {
-	case "JSON":
-		if len(call.Args) == 0 {
-			return ctxResponseUnknown, "", nil
-		}
-		return ctxResponseJSON, status, call.Args[0]
-	case "SendStatus":
-		if len(call.Args) > 0 {
-			if normalized := normalizeStatusLiteral(call.Args[0]); normalized != "" {
-				status = normalized
-			}
-		}
-		return ctxResponseEmpty, status, nil
-	case "SendFile", "SendStream", "Download":
-		return ctxResponseBinary, status, nil
-	case "SendString":
-		return ctxResponseText, status, callArgOrNil(call, 0)
-	case "Redirect":
-		if len(call.Args) > 1 {
-			if normalized := normalizeStatusLiteral(call.Args[1]); normalized != "" {
-				status = normalized
-			}
-		}
-		return ctxResponseEmpty, status, nil
-	default:
-		return ctxResponseUnknown, "", nil
+	if classifier, ok := handlerAnalyzerFor(info.framework).(responseClassifier); ok {
+		return classifier.classifyResponse(call, info)
 	}
+	return ctxResponseUnknown, "", nil
 }
 
 func callArgOrNil(call *ast.CallExpr, idx int) ast.Expr {
@@ -1160,7 +1377,7 @@ func collectCtxParams(fn *ast.FuncDecl) map[string]struct{} {
 			continue
 		}
 		typeName := strings.TrimSpace(exprToString(field.Type))
-		if !isFiberCtxType(typeName) {
+		if !isContextParamType(typeName) {
 			continue
 		}
 		for _, name := range field.Names {
@@ -1191,6 +1408,19 @@ func isFiberCtxType(typeName string) bool {
 }
 
 func ensureQueryStructParams(info *HandlerInfo, typeName string, registry *TypeRegistry) {
+	ensureQueryStructParamsWithTag(info, typeName, registry, "query")
+}
+
+// ensureQueryStructParamsWithTag records query parameters for the fields of typeName,
+// naming them after the given struct tag (query for Fiber, form for gin).
+func ensureQueryStructParamsWithTag(info *HandlerInfo, typeName string, registry *TypeRegistry, tagKey string) {
+	ensureStructParams(info, typeName, registry, tagKey, "query")
+}
+
+// ensureStructParams records a parameter located in in (query, path, header or
+// cookie) for each field of typeName, named after the given struct tag and
+// typed by the field.
+func ensureStructParams(info *HandlerInfo, typeName string, registry *TypeRegistry, tagKey, in string) {
 	if info == nil {
 		return
 	}
@@ -1213,24 +1443,33 @@ func ensureQueryStructParams(info *HandlerInfo, typeName string, registry *TypeR
 		return
 	}
 	for _, field := range structType.Fields.List {
-		names, allowMany := queryFieldNames(field)
+		names, allowMany := queryFieldNames(field, tagKey)
 		if len(names) == 0 {
 			continue
 		}
+		typ := strings.TrimPrefix(exprToString(field.Type), "*")
 		for _, name := range names {
-			ensureQueryParam(info, name, allowMany)
+			switch in {
+			case "query":
+				ensureQueryParam(info, name, allowMany)
+				ensureTypedQueryParam(info, name, typ, "")
+			case "path":
+				ensurePathParam(info, name, typ)
+			default:
+				ensureRequestParam(info, in, name, typ)
+			}
 		}
 	}
 }
 
-func queryFieldNames(field *ast.Field) ([]string, bool) {
+func queryFieldNames(field *ast.Field, tagKey string) ([]string, bool) {
 	if field == nil {
 		return nil, false
 	}
 	typeExpr := field.Type
 	isSlice := isSliceType(typeExpr)
 
-	nameFromTag := extractTag(field, "query")
+	nameFromTag := extractTag(field, tagKey)
 	if nameFromTag == "-" {
 		return nil, false
 	}
@@ -1283,21 +1522,40 @@ func isSliceType(expr ast.Expr) bool {
 	}
 }
 
-func parseResponseAnnotation(fields []string, info *HandlerInfo) {
-	if len(fields) < 4 {
+// parseResponseAnnotation parses the rest of an @Success or @Failure line:
+// 200 {object} model.User "description", 200 {array} model.User,
+// 200 {object} response.Envelope{data=model.User}, 200 {string} string "ok"
+// or 204 "No Content".
+func parseResponseAnnotation(rest string, info *HandlerInfo) {
+	parts := splitAnnotationFields(rest)
+	if len(parts) < 2 {
 		return
 	}
-	status := fields[1]
-	// swagger annotations often include {object} token at index 2.
-	typeIdx := 2
-	if fields[2] == "{object}" && len(fields) >= 4 {
-		typeIdx = 3
-	}
-	if typeIdx >= len(fields) {
+	status := parts[0]
+	if strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), status)), `"`) {
+		// No type: a response without a body.
+		setResponseDescription(info, status, strings.Join(parts[1:], " "))
+		if info.EmptyBodyStatus == nil {
+			info.EmptyBodyStatus = make(map[string]bool)
+		}
+		info.EmptyBodyStatus[status] = true
 		return
 	}
-	typ := cleanTypeToken(fields[typeIdx])
-	if typ == "" {
+	parts = parts[1:]
+	kind := ""
+	if strings.HasPrefix(parts[0], "{") && strings.HasSuffix(parts[0], "}") {
+		kind = strings.Trim(parts[0], "{}")
+		parts = parts[1:]
+	}
+	typ := kind
+	if len(parts) > 0 {
+		typ = cleanTypeToken(parts[0])
+		parts = parts[1:]
+	}
+	if kind == "array" {
+		typ = "[]" + typ
+	}
+	if typ == "" || typ == "[]" {
 		return
 	}
 	if info.Responses == nil {
@@ -1307,6 +1565,28 @@ func parseResponseAnnotation(fields []string, info *HandlerInfo) {
 	if status == "200" && info.OutputType == "" {
 		info.OutputType = typ
 	}
+	if info.annotated == nil {
+		info.annotated = make(map[string]struct{})
+	}
+	info.annotated[status] = struct{}{}
+	setResponseDescription(info, status, strings.Join(parts, " "))
+}
+
+// isGodocMarker reports whether line is the "listUsers godoc" line swag
+// projects open their annotations with.
+func isGodocMarker(line string) bool {
+	fields := strings.Fields(line)
+	return len(fields) == 2 && fields[1] == "godoc" && token.IsIdentifier(fields[0])
+}
+
+func setResponseDescription(info *HandlerInfo, status, description string) {
+	if description = strings.TrimSpace(description); description == "" {
+		return
+	}
+	if info.ResponseDescriptions == nil {
+		info.ResponseDescriptions = make(map[string]string)
+	}
+	info.ResponseDescriptions[status] = description
 }
 
 func parseParamAnnotation(rest string, info *HandlerInfo) {
@@ -1321,9 +1601,16 @@ func parseParamAnnotation(rest string, info *HandlerInfo) {
 	if len(parts) >= 4 {
 		required = parseBoolToken(parts[3])
 	}
-	description := ""
+	var (
+		attrs       Parameter
+		description []string
+	)
 	if len(parts) >= 5 {
-		description = strings.Join(parts[4:], " ")
+		for _, part := range parts[4:] {
+			if !applyParamAttribute(part, &attrs) {
+				description = append(description, part)
+			}
+		}
 	}
 
 	switch location {
@@ -1338,27 +1625,25 @@ func parseParamAnnotation(rest string, info *HandlerInfo) {
 		return
 	case "path":
 		required = true
-	case "formData":
-		info.FormParams = append(info.FormParams, Parameter{
-			Name:        name,
-			In:          location,
-			Type:        typeToken,
-			Required:    required,
-			Description: description,
-		})
-		return
 	}
 
-	info.Params = append(info.Params, Parameter{
-		Name:        name,
-		In:          location,
-		Type:        typeToken,
-		Required:    required,
-		Description: description,
-	})
+	param := attrs
+	param.Name = name
+	param.In = location
+	param.Type = typeToken
+	param.Required = required
+	param.Description = strings.Join(description, " ")
+	if location == "formData" {
+		info.FormParams = append(info.FormParams, param)
+		return
+	}
+	info.Params = append(info.Params, param)
 }
 
 func inferTypeFromExpr(expr ast.Expr, registry *TypeRegistry) string {
+	if typ := registry.typedExprType(expr); typ != "" {
+		return typ
+	}
 	switch v := expr.(type) {
 	case *ast.CompositeLit:
 		return exprToString(v.Type)
@@ -1592,6 +1877,10 @@ func normalizeStatusLiteral(expr ast.Expr) string {
 		if code, ok := httpStatusLookup[rendered]; ok {
 			return code
 		}
+		// fiber.StatusCreated and friends mirror the net/http names.
+		if code, ok := httpStatusLookup["http."+v.Sel.Name]; ok {
+			return code
+		}
 		return rendered
 	case *ast.Ident:
 		return v.Name
@@ -1624,6 +1913,7 @@ func splitAnnotationFields(input string) []string {
 		current strings.Builder
 		inQuote bool
 		escape  bool
+		depth   int // inside the parentheses of Enums(a, b) style attributes
 	)
 	for _, r := range input {
 		switch {
@@ -1634,7 +1924,13 @@ func splitAnnotationFields(input string) []string {
 			escape = true
 		case r == '"':
 			inQuote = !inQuote
-		case (r == ' ' || r == '\t') && !inQuote:
+		case r == '(' && !inQuote:
+			depth++
+			current.WriteRune(r)
+		case r == ')' && !inQuote && depth > 0:
+			depth--
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && !inQuote && depth == 0:
 			if current.Len() > 0 {
 				result = append(result, current.String())
 				current.Reset()
@@ -1708,7 +2004,7 @@ func schemaFromArrayLiteral(comp *ast.CompositeLit, varTypes map[string]string,
 		itemSchema = schemaForValueExpr(comp.Elts[0], varTypes, registry, info)
 	}
 	if itemSchema == nil && arrayType != nil {
-		itemSchema = schemaFromTypeString(exprToString(arrayType.Elt), info)
+		itemSchema = schemaFromTypeString(exprToString(arrayType.Elt), registry, info)
 	}
 	if itemSchema == nil {
 		itemSchema = Schema{"type": "object"}
@@ -1750,6 +2046,28 @@ func schemaFromMapLiteral(comp *ast.CompositeLit, varTypes map[string]string, re
 	return schema
 }
 
+// literalValue returns a basic literal argument as written, unquoting strings
+// and accepting true, false and negative numbers. It returns "" otherwise.
+func literalValue(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		if v.Kind == token.STRING {
+			s, _ := stringLiteral(v)
+			return s
+		}
+		return v.Value
+	case *ast.Ident:
+		if v.Name == "true" || v.Name == "false" {
+			return v.Name
+		}
+	case *ast.UnaryExpr:
+		if lit, ok := v.X.(*ast.BasicLit); ok && v.Op == token.SUB && lit.Kind != token.STRING {
+			return "-" + lit.Value
+		}
+	}
+	return ""
+}
+
 func literalKeyToString(expr ast.Expr) string {
 	switch v := expr.(type) {
 	case *ast.BasicLit:
@@ -1786,23 +2104,23 @@ func schemaForValueExpr(expr ast.Expr, varTypes map[string]string, registry *Typ
 			return Schema{"type": "boolean"}
 		}
 		if typeName := varTypes[v.Name]; typeName != "" {
-			return schemaFromTypeString(typeName, info)
+			return schemaFromTypeString(typeName, registry, info)
 		}
 		return Schema{"type": "string"}
 	case *ast.SelectorExpr:
 		typeName := exprToString(v)
 		if typeName != "" {
-			return schemaFromTypeString(typeName, info)
+			return schemaFromTypeString(typeName, registry, info)
 		}
 	case *ast.CallExpr:
 		if typeName := inferTypeFromExpr(v, registry); typeName != "" {
-			return schemaFromTypeString(typeName, info)
+			return schemaFromTypeString(typeName, registry, info)
 		}
 	}
 	return Schema{"type": "string"}
 }
 
-func schemaFromTypeString(typeName string, info *HandlerInfo) Schema {
+func schemaFromTypeString(typeName string, registry *TypeRegistry, info *HandlerInfo) Schema {
 	if typeName == "" {
 		return Schema{"type": "string"}
 	}
@@ -1811,7 +2129,7 @@ func schemaFromTypeString(typeName string, info *HandlerInfo) Schema {
 		t = strings.TrimPrefix(t, "*")
 	}
 	if strings.HasPrefix(t, "[]") {
-		items := schemaFromTypeString(t[2:], info)
+		items := schemaFromTypeString(t[2:], registry, info)
 		return Schema{
 			"type":  "array",
 			"items": items,
@@ -1832,7 +2150,7 @@ func schemaFromTypeString(typeName string, info *HandlerInfo) Schema {
 	case "[]byte":
 		return Schema{"type": "string", "format": "byte"}
 	}
-	component := buildComponentName(t)
+	component := registry.componentName(t)
 	if component != "" {
 		if info != nil {
 			recordNeededComponent(info, t)
@@ -1891,7 +2209,9 @@ func splitCSV(value string) []string {
 
 func cleanTypeToken(token string) string {
 	token = strings.TrimSpace(token)
-	token = strings.Trim(token, "{}")
+	if strings.HasPrefix(token, "{") && strings.HasSuffix(token, "}") {
+		token = strings.Trim(token, "{}")
+	}
 	token = strings.TrimPrefix(token, "*")
 	return token
 }
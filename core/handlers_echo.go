@@ -0,0 +1,141 @@
+package core
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// echoAnalyzer analyses handlers taking echo.Context.
+type echoAnalyzer struct{}
+
+func (echoAnalyzer) IsContextParam(typeName string) bool {
+	return isEchoContextType(typeName)
+}
+
+func (echoAnalyzer) AnalyzeCall(call *ast.CallExpr, hc *HandlerContext) bool {
+	return processEchoCall(call, hc.Info, hc.varTypes)
+}
+
+func (echoAnalyzer) classifyResponse(call *ast.CallExpr, info *HandlerInfo) (ctxResponseKind, string, ast.Expr) {
+	return classifyEchoResponseCall(call, info)
+}
+
+func isEchoContextType(typeName string) bool {
+	return typeName == "echo.Context"
+}
+
+// processEchoCall handles the request side of echo.Context: c.Bind(&v),
+// c.Param("id"), c.QueryParam("q"), form values and files, and echo.NewHTTPError(code, ...)
+// error returns.
+// Responses are classified by classifyEchoResponseCall.
+func processEchoCall(call *ast.CallExpr, info *HandlerInfo, varTypes map[string]string) bool {
+	if call == nil || info == nil {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return false
+	}
+	if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "echo" && sel.Sel.Name == "NewHTTPError" {
+		if status := normalizeStatusLiteral(callArgOrNil(call, 0)); status != "" {
+			ensureEchoErrorResponse(info, status)
+		}
+		return true
+	}
+	if !isVarIn(sel.X, info.ctxVars) {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "Bind":
+		if len(call.Args) == 0 {
+			return false
+		}
+		setInputTypeFromArg(info, call.Args[0], varTypes)
+	case "Param":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensurePathParam(info, name, "string")
+		}
+	case "QueryParam":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensureQueryParam(info, name, false)
+		}
+	case "FormValue":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensureFormParam(info, Parameter{Name: name, In: "formData", Type: "string"})
+		}
+	case "FormFile":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensureFormParam(info, Parameter{Name: name, In: "formData", Type: "file", Required: true})
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// classifyEchoResponseCall maps echo's status-first writers (c.JSON(code, v),
+// c.NoContent(code), c.String(code, s), ...) to response kinds.
+func classifyEchoResponseCall(call *ast.CallExpr, info *HandlerInfo) (ctxResponseKind, string, ast.Expr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil || !isVarIn(sel.X, info.ctxVars) {
+		return ctxResponseUnknown, "", nil
+	}
+	status := ""
+	if len(call.Args) > 0 {
+		status = normalizeStatusLiteral(call.Args[0])
+	}
+	switch sel.Sel.Name {
+	case "JSON", "JSONPretty":
+		if len(call.Args) < 2 {
+			return ctxResponseUnknown, "", nil
+		}
+		return ctxResponseJSON, status, call.Args[1]
+	case "XML", "XMLPretty":
+		if len(call.Args) < 2 {
+			return ctxResponseUnknown, "", nil
+		}
+		return ctxResponseXML, status, call.Args[1]
+	case "JSONBlob":
+		return ctxResponseJSON, status, nil
+	case "XMLBlob":
+		return ctxResponseXML, status, nil
+	case "String":
+		return ctxResponseText, status, callArgOrNil(call, 1)
+	case "HTML", "HTMLBlob":
+		return ctxResponseHTML, status, nil
+	case "Blob", "Stream":
+		return ctxResponseBinary, status, nil
+	case "File", "Attachment", "Inline":
+		return ctxResponseBinary, "200", nil
+	case "NoContent", "Redirect":
+		return ctxResponseEmpty, status, nil
+	default:
+		return ctxResponseUnknown, "", nil
+	}
+}
+
+// ensureEchoErrorResponse documents the {"message": ...} body echo's default
+// error handler writes for *echo.HTTPError.
+func ensureEchoErrorResponse(info *HandlerInfo, status string) {
+	if info == nil || strings.TrimSpace(status) == "" {
+		return
+	}
+	if info.Responses == nil {
+		info.Responses = make(map[string]string)
+	}
+	if _, exists := info.Responses[status]; !exists {
+		info.Responses[status] = "echo.HTTPError"
+	}
+	if info.ResponseSchemas == nil {
+		info.ResponseSchemas = make(map[string]Schema)
+	}
+	if _, exists := info.ResponseSchemas[status]; !exists {
+		info.ResponseSchemas[status] = Schema{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"message": map[string]interface{}{"type": "string"},
+			},
+			"required": []string{"message"},
+		}
+	}
+}
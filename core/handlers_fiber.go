@@ -0,0 +1,216 @@
+package core
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// fiberAnalyzer analyses handlers taking *fiber.Ctx. It is also the fallback for
+// handlers whose context type is not recognised.
+type fiberAnalyzer struct{}
+
+func (fiberAnalyzer) IsContextParam(typeName string) bool {
+	return isFiberCtxType(typeName)
+}
+
+func (fiberAnalyzer) AnalyzeCall(call *ast.CallExpr, hc *HandlerContext) bool {
+	return processFiberCall(call, hc.Info, hc.varTypes, hc.Registry)
+}
+
+func (fiberAnalyzer) classifyResponse(call *ast.CallExpr, info *HandlerInfo) (ctxResponseKind, string, ast.Expr) {
+	return classifyFiberResponseCall(call, info)
+}
+
+// processFiberCall handles the request side of *fiber.Ctx: BodyParser, form
+// values and files, Query* lookups, QueryParser, ParamsInt and ParamsParser,
+// header and cookie lookups (Get, Cookies) and ReqHeaderParser/CookieParser.
+func processFiberCall(call *ast.CallExpr, info *HandlerInfo, varTypes map[string]string, registry *TypeRegistry) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "BodyParser":
+		if len(call.Args) == 0 {
+			return true
+		}
+		setInputTypeFromArg(info, call.Args[0], varTypes)
+	case "FormFile":
+		if len(call.Args) == 0 {
+			return true
+		}
+		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if name, err := strconv.Unquote(lit.Value); err == nil {
+				ensureFormParam(info, Parameter{
+					Name:     name,
+					In:       "formData",
+					Type:     "file",
+					Required: true,
+				})
+			}
+		}
+	case "FormValue":
+		if len(call.Args) == 0 {
+			return true
+		}
+		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if name, err := strconv.Unquote(lit.Value); err == nil {
+				ensureFormParam(info, Parameter{
+					Name:        name,
+					In:          "formData",
+					Type:        "string",
+					Required:    false,
+					Description: "",
+				})
+			}
+		}
+	case "Query", "QueryInt", "QueryBool", "QueryFloat":
+		if len(call.Args) == 0 {
+			return true
+		}
+		if name, ok := stringLiteral(call.Args[0]); ok {
+			ensureTypedQueryParam(info, name, fiberLookupTypes[sel.Sel.Name], literalValue(callArgOrNil(call, 1)))
+		}
+	case "ParamsInt":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensurePathParam(info, name, "int")
+		}
+	case "ParamsParser":
+		if len(call.Args) == 0 {
+			return true
+		}
+		ensureStructParams(info, bindTargetType(call.Args[0], varTypes, registry), registry, "params", "path")
+	case "QueryParser":
+		if len(call.Args) == 0 {
+			return true
+		}
+		typeName := strings.TrimSpace(inferTypeFromExpr(call.Args[0], registry))
+		if direct := strings.TrimSpace(varTypes[typeName]); direct != "" {
+			typeName = direct
+		}
+		if typeName == "" {
+			if unary, ok := call.Args[0].(*ast.UnaryExpr); ok && unary.Op == token.AND {
+				if ident, ok := unary.X.(*ast.Ident); ok {
+					typeName = varTypes[ident.Name]
+				}
+			}
+		}
+		ensureQueryStructParams(info, typeName, registry)
+	case "ReqHeaderParser", "CookieParser":
+		if len(call.Args) == 0 {
+			return true
+		}
+		in, tagKey := "header", "reqHeader"
+		if sel.Sel.Name == "CookieParser" {
+			in, tagKey = "cookie", "cookie"
+		}
+		ensureStructParams(info, bindTargetType(call.Args[0], varTypes, registry), registry, tagKey, in)
+	case "Get", "Cookies":
+		if !isVarIn(sel.X, info.ctxVars) || len(call.Args) == 0 {
+			return false
+		}
+		in := "header"
+		if sel.Sel.Name == "Cookies" {
+			in = "cookie"
+		}
+		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if name, err := strconv.Unquote(lit.Value); err == nil {
+				ensureRequestParam(info, in, name, "")
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// fiberLookupTypes are the Go types of the values returned by Fiber's query
+// lookups; the optional second argument is the default.
+var fiberLookupTypes = map[string]string{
+	"Query":      "string",
+	"QueryInt":   "int",
+	"QueryBool":  "bool",
+	"QueryFloat": "float64",
+}
+
+// trackFiberHeaderVars remembers variables bound to c.GetReqHeaders().
+func trackFiberHeaderVars(assign *ast.AssignStmt, info *HandlerInfo, state *handlerState) {
+	if assign == nil || state == nil || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+	if isReqHeadersCall(assign.Rhs[0], info) {
+		state.headerVars[ident.Name] = struct{}{}
+	}
+}
+
+// processFiberHeaderIndex records the header read by headers["X-Request-ID"]
+// on the map returned by c.GetReqHeaders().
+func processFiberHeaderIndex(index *ast.IndexExpr, info *HandlerInfo, state *handlerState) {
+	if index == nil || state == nil {
+		return
+	}
+	if !isVarIn(index.X, state.headerVars) && !isReqHeadersCall(index.X, info) {
+		return
+	}
+	if lit, ok := index.Index.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if name, err := strconv.Unquote(lit.Value); err == nil {
+			ensureRequestParam(info, "header", name, "")
+		}
+	}
+}
+
+func isReqHeadersCall(expr ast.Expr, info *HandlerInfo) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "GetReqHeaders" && isVarIn(sel.X, info.ctxVars)
+}
+
+// classifyFiberResponseCall maps c.JSON(v), c.Status(code).JSON(v), c.SendStatus,
+// c.SendString, c.SendFile and friends to response kinds.
+func classifyFiberResponseCall(call *ast.CallExpr, info *HandlerInfo) (ctxResponseKind, string, ast.Expr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return ctxResponseUnknown, "", nil
+	}
+	method := sel.Sel.Name
+	status, ok := unwrapCtxReceiver(sel.X, info)
+	if !ok {
+		return ctxResponseUnknown, "", nil
+	}
+	switch method {
+	case "JSON":
+		if len(call.Args) == 0 {
+			return ctxResponseUnknown, "", nil
+		}
+		return ctxResponseJSON, status, call.Args[0]
+	case "SendStatus":
+		if len(call.Args) > 0 {
+			if normalized := normalizeStatusLiteral(call.Args[0]); normalized != "" {
+				status = normalized
+			}
+		}
+		return ctxResponseEmpty, status, nil
+	case "SendFile", "SendStream", "Download":
+		return ctxResponseBinary, status, nil
+	case "SendString":
+		return ctxResponseText, status, callArgOrNil(call, 0)
+	case "Redirect":
+		if len(call.Args) > 1 {
+			if normalized := normalizeStatusLiteral(call.Args[1]); normalized != "" {
+				status = normalized
+			}
+		}
+		return ctxResponseEmpty, status, nil
+	default:
+		return ctxResponseUnknown, "", nil
+	}
+}
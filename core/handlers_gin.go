@@ -0,0 +1,198 @@
+package core
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// ginAnalyzer analyses handlers taking *gin.Context.
+type ginAnalyzer struct{}
+
+func (ginAnalyzer) IsContextParam(typeName string) bool {
+	return isGinContextType(typeName)
+}
+
+func (ginAnalyzer) AnalyzeCall(call *ast.CallExpr, hc *HandlerContext) bool {
+	if processGinWriterCall(call, hc.Info, hc.varTypes, hc.state, hc.Registry) {
+		return true
+	}
+	if kind, status, _ := classifyGinResponseCall(call, hc.Info); kind != ctxResponseEmpty && status != "" && status == hc.state.status {
+		// c.Status(code) before a renderer sending the same code is not an empty response.
+		hc.state.written = true
+	}
+	return processGinCall(call, hc.Info, hc.varTypes, hc.Registry)
+}
+
+func (ginAnalyzer) classifyResponse(call *ast.CallExpr, info *HandlerInfo) (ctxResponseKind, string, ast.Expr) {
+	return classifyGinResponseCall(call, info)
+}
+
+func isGinContextType(typeName string) bool {
+	return strings.TrimPrefix(typeName, "*") == "gin.Context"
+}
+
+// processGinCall handles the request side of *gin.Context: body and query binding,
+// Param, Query/DefaultQuery/QueryArray/GetQuery lookups, PostForm values and FormFile.
+// Responses are classified by classifyGinResponseCall.
+func processGinCall(call *ast.CallExpr, info *HandlerInfo, varTypes map[string]string, registry *TypeRegistry) bool {
+	if call == nil || info == nil {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil || !isVarIn(sel.X, info.ctxVars) {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "ShouldBindJSON", "BindJSON", "ShouldBind", "Bind", "ShouldBindWith", "ShouldBindBodyWith",
+		"ShouldBindXML", "BindXML", "ShouldBindYAML", "BindYAML":
+		if len(call.Args) == 0 {
+			return false
+		}
+		setInputTypeFromArg(info, call.Args[0], varTypes)
+	case "ShouldBindQuery", "BindQuery":
+		if len(call.Args) == 0 {
+			return false
+		}
+		ensureQueryStructParamsWithTag(info, bindTargetType(call.Args[0], varTypes, registry), registry, "form")
+	case "Param":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensurePathParam(info, name, "string")
+		}
+	case "Query", "GetQuery":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensureQueryParam(info, name, false)
+		}
+	case "DefaultQuery":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensureTypedQueryParam(info, name, "string", literalValue(callArgOrNil(call, 1)))
+		}
+	case "QueryArray", "GetQueryArray":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensureQueryParam(info, name, true)
+		}
+	case "PostForm", "DefaultPostForm", "GetPostForm":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensureFormParam(info, Parameter{Name: name, In: "formData", Type: "string"})
+		}
+	case "FormFile":
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			ensureFormParam(info, Parameter{Name: name, In: "formData", Type: "file", Required: true})
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// ginEncodedKinds maps gin's non-JSON renderers to their response kinds.
+var ginEncodedKinds = map[string]ctxResponseKind{
+	"XML":      ctxResponseXML,
+	"YAML":     ctxResponseYAML,
+	"ProtoBuf": ctxResponseProtoBuf,
+}
+
+// processGinWriterCall handles a status set apart from the body: c.Status(code)
+// or c.Writer.WriteHeader(code) followed by json.NewEncoder(c.Writer).Encode(v)
+// or c.Writer.Write. A status no body follows is documented as empty.
+func processGinWriterCall(call *ast.CallExpr, info *HandlerInfo, varTypes map[string]string, state *handlerState, registry *TypeRegistry) bool {
+	if call == nil || info == nil || state == nil {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "Status", "WriteHeader":
+		receiver := isVarIn(sel.X, info.ctxVars)
+		if sel.Sel.Name == "WriteHeader" {
+			receiver = isGinWriter(sel.X, info)
+		}
+		if !receiver || len(call.Args) == 0 {
+			return false
+		}
+		state.finish(info)
+		state.status = normalizeStatusLiteral(call.Args[0])
+		state.written = false
+	case "Encode":
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok || functionName(inner) != "NewEncoder" || len(call.Args) == 0 || !isGinWriter(callArgOrNil(inner, 0), info) {
+			return false
+		}
+		addResponseFromExpr(info, state.pending(), call.Args[0], varTypes, registry)
+		state.written = true
+	case "Write":
+		if !isGinWriter(sel.X, info) {
+			return false
+		}
+		ensureBinaryResponse(info, state.pending())
+		info.Produces = appendUnique(info.Produces, "application/octet-stream")
+		state.written = true
+	case "WriteString":
+		if !isGinWriter(sel.X, info) {
+			return false
+		}
+		ensureTextResponse(info, state.pending())
+		info.Produces = appendUnique(info.Produces, "text/plain")
+		state.written = true
+	default:
+		return false
+	}
+	return true
+}
+
+// isGinWriter reports whether expr is c.Writer for a context parameter c.
+func isGinWriter(expr ast.Expr, info *HandlerInfo) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && sel.Sel != nil && sel.Sel.Name == "Writer" && isVarIn(sel.X, info.ctxVars)
+}
+
+// classifyGinResponseCall maps gin's status-first writers (c.JSON(code, v),
+// c.String(code, ...), c.AbortWithStatusJSON(code, v), ...) to response kinds.
+func classifyGinResponseCall(call *ast.CallExpr, info *HandlerInfo) (ctxResponseKind, string, ast.Expr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil || !isVarIn(sel.X, info.ctxVars) {
+		return ctxResponseUnknown, "", nil
+	}
+	status := ""
+	if len(call.Args) > 0 {
+		status = normalizeStatusLiteral(call.Args[0])
+	}
+	switch sel.Sel.Name {
+	case "JSON", "IndentedJSON", "SecureJSON", "PureJSON", "AsciiJSON", "JSONP", "AbortWithStatusJSON":
+		if len(call.Args) < 2 {
+			return ctxResponseUnknown, "", nil
+		}
+		return ctxResponseJSON, status, call.Args[1]
+	case "XML", "YAML", "ProtoBuf":
+		if len(call.Args) < 2 {
+			return ctxResponseUnknown, "", nil
+		}
+		return ginEncodedKinds[sel.Sel.Name], status, call.Args[1]
+	case "String":
+		return ctxResponseText, status, callArgOrNil(call, 1)
+	case "Data", "DataFromReader":
+		return ctxResponseBinary, status, nil
+	case "File", "FileAttachment", "FileFromFS":
+		return ctxResponseBinary, "200", nil
+	case "AbortWithStatus", "AbortWithError", "Redirect":
+		return ctxResponseEmpty, status, nil
+	default:
+		return ctxResponseUnknown, "", nil
+	}
+}
+
+// bindTargetType resolves the type behind a binding target such as &filter,
+// or a pointer variable from new(Filter).
+func bindTargetType(arg ast.Expr, varTypes map[string]string, registry *TypeRegistry) string {
+	target := arg
+	if unary, ok := arg.(*ast.UnaryExpr); ok {
+		target = unary.X
+	}
+	if ident, ok := target.(*ast.Ident); ok {
+		if typ := strings.TrimSpace(varTypes[ident.Name]); typ != "" {
+			return typ
+		}
+	}
+	return strings.TrimSpace(inferTypeFromExpr(arg, registry))
+}
@@ -219,6 +219,38 @@ func TestProcessCtxResponseCallText(t *testing.T) {
 	}
 }
 
+func TestProcessCtxResponseCallEchoMediaTypes(t *testing.T) {
+	tests := []struct {
+		call  string
+		media string
+	}{
+		{`c.HTML(200, "<p>hi</p>")`, "text/html"},
+		{`c.HTMLBlob(200, page)`, "text/html"},
+		{`c.JSONBlob(200, raw)`, ""},
+		{`c.XMLBlob(200, raw)`, "application/xml"},
+		{`c.XML(200, item)`, "application/xml"},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.call)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tt.call, err)
+		}
+		info := &HandlerInfo{framework: "echo", ctxVars: map[string]struct{}{"c": {}}}
+		if !processCtxResponseCall(expr.(*ast.CallExpr), info, map[string]string{"item": "Item"}, NewTypeRegistry()) {
+			t.Fatalf("%s: expected a response", tt.call)
+		}
+		if _, ok := info.ResponseSchemas["200"]; !ok && info.Responses["200"] == "" {
+			t.Fatalf("%s: no 200 response documented", tt.call)
+		}
+		if got := info.responseMedia["200"]; got != tt.media {
+			t.Fatalf("%s: media type = %q, want %q", tt.call, got, tt.media)
+		}
+		if len(info.Produces) != 0 {
+			t.Fatalf("%s: produces = %v, want none", tt.call, info.Produces)
+		}
+	}
+}
+
 func TestCollectCtxParams(t *testing.T) {
 	code := "package x\nfunc handler(ctx *fiber.Ctx, id string){}"
 	fset := token.NewFileSet()
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// httpAnalyzer analyses net/http handlers, (w http.ResponseWriter, r *http.Request),
+// which is also the handler shape chi uses.
+type httpAnalyzer struct{}
+
+func (httpAnalyzer) IsContextParam(typeName string) bool {
+	return isHTTPResponseWriterType(typeName)
+}
+
+func (httpAnalyzer) AnalyzeCall(call *ast.CallExpr, hc *HandlerContext) bool {
+	return processHTTPCall(call, hc.Info, hc.varTypes, hc.state, hc.Registry)
+}
+
+// trackHTTPQueryVars remembers variables bound to r.URL.Query().
+func trackHTTPQueryVars(assign *ast.AssignStmt, info *HandlerInfo, state *handlerState) {
+	if assign == nil || state == nil || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+	if isRequestQueryCall(assign.Rhs[0], info) {
+		state.queryVars[ident.Name] = struct{}{}
+	}
+}
+
+// processHTTPCall interprets net/http idioms: json.NewDecoder(r.Body).Decode(&v),
+// json.NewEncoder(w).Encode(v), w.WriteHeader(code), r.URL.Query().Get("q"),
+// r.FormValue/r.FormFile and the http.Error/NotFound/Redirect/ServeFile helpers.
+func processHTTPCall(call *ast.CallExpr, info *HandlerInfo, varTypes map[string]string, state *handlerState, registry *TypeRegistry) bool {
+	if call == nil || info == nil || state == nil {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return false
+	}
+
+	if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "http" {
+		return processHTTPHelperCall(sel.Sel.Name, call, info)
+	}
+
+	switch sel.Sel.Name {
+	case "WriteHeader":
+		if !isVarIn(sel.X, info.ctxVars) || len(call.Args) == 0 {
+			return false
+		}
+		state.finish(info)
+		state.status = normalizeStatusLiteral(call.Args[0])
+		state.written = false
+		return true
+	case "Encode":
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok || functionName(inner) != "NewEncoder" || len(inner.Args) == 0 || len(call.Args) == 0 {
+			return false
+		}
+		if !isVarIn(inner.Args[0], info.ctxVars) {
+			return false
+		}
+		addResponseFromExpr(info, state.pending(), call.Args[0], varTypes, registry)
+		state.written = true
+		return true
+	case "Decode":
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok || functionName(inner) != "NewDecoder" || len(inner.Args) == 0 || len(call.Args) == 0 {
+			return false
+		}
+		body, ok := inner.Args[0].(*ast.SelectorExpr)
+		if !ok || body.Sel.Name != "Body" || !isVarIn(body.X, info.requestVars) {
+			return false
+		}
+		setInputTypeFromArg(info, call.Args[0], varTypes)
+		return true
+	case "Get":
+		if len(call.Args) == 0 {
+			return false
+		}
+		if !isRequestQueryCall(sel.X, info) && !isVarIn(sel.X, state.queryVars) {
+			return false
+		}
+		if name, ok := stringLiteral(call.Args[0]); ok {
+			ensureQueryParam(info, name, false)
+		}
+		return true
+	case "FormValue", "PostFormValue", "FormFile":
+		if !isVarIn(sel.X, info.requestVars) {
+			return false
+		}
+		if name, ok := stringLiteral(callArgOrNil(call, 0)); ok {
+			param := Parameter{Name: name, In: "formData", Type: "string"}
+			if sel.Sel.Name == "FormFile" {
+				param.Type = "file"
+				param.Required = true
+			}
+			ensureFormParam(info, param)
+		}
+		return true
+	}
+	return false
+}
+
+func processHTTPHelperCall(name string, call *ast.CallExpr, info *HandlerInfo) bool {
+	if len(call.Args) == 0 || !isVarIn(call.Args[0], info.ctxVars) {
+		return false
+	}
+	switch name {
+	case "Error":
+		if len(call.Args) < 3 {
+			return false
+		}
+		ensureEmptyResponse(info, normalizeStatusLiteral(call.Args[2]))
+	case "NotFound":
+		ensureEmptyResponse(info, "404")
+	case "Redirect":
+		if len(call.Args) < 4 {
+			return false
+		}
+		ensureEmptyResponse(info, normalizeStatusLiteral(call.Args[3]))
+	case "ServeFile", "ServeContent":
+		ensureBinaryResponse(info, "200")
+		info.Produces = appendUnique(info.Produces, "application/octet-stream")
+	default:
+		return false
+	}
+	return true
+}
+
+// isRequestQueryCall reports whether expr is r.URL.Query() for a request parameter r.
+func isRequestQueryCall(expr ast.Expr, info *HandlerInfo) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Query" {
+		return false
+	}
+	urlSel, ok := sel.X.(*ast.SelectorExpr)
+	if !ok || urlSel.Sel.Name != "URL" {
+		return false
+	}
+	return isVarIn(urlSel.X, info.requestVars)
+}
+
+func isVarIn(expr ast.Expr, vars map[string]struct{}) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || vars == nil {
+		return false
+	}
+	_, exists := vars[ident.Name]
+	return exists
+}
+
+func collectHTTPRequestParams(fn *ast.FuncDecl) map[string]struct{} {
+	result := make(map[string]struct{})
+	if fn == nil || fn.Type == nil || fn.Type.Params == nil {
+		return result
+	}
+	for _, field := range fn.Type.Params.List {
+		if field == nil || strings.TrimSpace(exprToString(field.Type)) != "*http.Request" {
+			continue
+		}
+		for _, name := range field.Names {
+			if name != nil && name.Name != "" {
+				result[name.Name] = struct{}{}
+			}
+		}
+	}
+	return result
+}
+
+func isHTTPResponseWriterType(typeName string) bool {
+	return typeName == "http.ResponseWriter"
+}
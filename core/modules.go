@@ -0,0 +1,281 @@
+package core
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// moduleDir is a module whose source lives in a local directory.
+type moduleDir struct {
+	path     string // module path
+	dir      string // absolute directory holding the module's go.mod
+	external bool   // a dependency read from vendor/ or the module cache
+}
+
+// moduleSet maps import paths to package directories for one generation root:
+// the module containing root, the other modules used by an enclosing go.work,
+// the local directories named by their replace directives and, read-only, the
+// required modules found in vendor/ or the module cache.
+type moduleSet struct {
+	modules []moduleDir // longest module path first, so nested modules win
+}
+
+// loadModuleSet collects the modules visible from root. Modules that cannot be
+// read are left out; an empty set resolves nothing.
+func loadModuleSet(root string) *moduleSet {
+	set := &moduleSet{}
+	seen := make(map[string]struct{})
+	add := func(path, dir string, external bool) {
+		if path == "" || dir == "" {
+			return
+		}
+		if _, dup := seen[path]; dup {
+			return
+		}
+		seen[path] = struct{}{}
+		set.modules = append(set.modules, moduleDir{path: path, dir: dir, external: external})
+	}
+
+	var (
+		mains     []string
+		vendorDir string
+		versioned = make(map[string]module.Version) // replace old => new@version
+	)
+	if workPath := findWorkFile(root); workPath != "" {
+		if work := readWorkFile(workPath); work != nil {
+			workDir := filepath.Dir(workPath)
+			vendorDir = filepath.Join(workDir, "vendor")
+			// Workspace replaces take precedence over those of the modules.
+			for _, r := range work.Replace {
+				if dir := localReplaceDir(workDir, r); dir != "" {
+					add(r.Old.Path, dir, false)
+				} else if r.New.Version != "" {
+					versioned[r.Old.Path] = r.New
+				}
+			}
+			for _, use := range work.Use {
+				mains = append(mains, absDir(workDir, use.Path))
+			}
+		}
+	}
+	if len(mains) == 0 {
+		if modRoot, err := FindModuleRoot(root); err == nil {
+			mains = append(mains, modRoot)
+			vendorDir = filepath.Join(modRoot, "vendor")
+		}
+	}
+
+	var replaces []moduleDir
+	requires := make(map[string]string) // module path -> highest required version
+	for _, dir := range mains {
+		mod := readModFile(dir)
+		if mod == nil || mod.Module == nil {
+			continue
+		}
+		add(mod.Module.Mod.Path, dir, false)
+		for _, r := range mod.Replace {
+			if target := localReplaceDir(dir, r); target != "" {
+				replaces = append(replaces, moduleDir{path: r.Old.Path, dir: target})
+			} else if _, ok := versioned[r.Old.Path]; !ok && r.New.Version != "" {
+				versioned[r.Old.Path] = r.New
+			}
+		}
+		for _, req := range mod.Require {
+			if current, ok := requires[req.Mod.Path]; !ok || semver.Compare(req.Mod.Version, current) > 0 {
+				requires[req.Mod.Path] = req.Mod.Version
+			}
+		}
+	}
+	for _, r := range replaces {
+		add(r.path, r.dir, false)
+	}
+
+	// Dependencies are read from vendor/ when the project is vendored and from
+	// the module cache otherwise; nothing is downloaded.
+	vendored := fileExists(filepath.Join(vendorDir, "modules.txt"))
+	cache := moduleCacheDir()
+	for path, version := range requires {
+		if _, dup := seen[path]; dup {
+			continue
+		}
+		var dir string
+		switch {
+		case vendored:
+			dir = filepath.Join(vendorDir, filepath.FromSlash(path))
+		case cache != "":
+			mod := module.Version{Path: path, Version: version}
+			if r, ok := versioned[path]; ok {
+				mod = r
+			}
+			dir = moduleCachePath(cache, mod)
+		}
+		if dir != "" && dirExists(dir) {
+			add(path, dir, true)
+		}
+	}
+
+	sort.SliceStable(set.modules, func(i, j int) bool {
+		if len(set.modules[i].path) != len(set.modules[j].path) {
+			return len(set.modules[i].path) > len(set.modules[j].path)
+		}
+		return set.modules[i].path < set.modules[j].path
+	})
+	return set
+}
+
+// importDir returns the directory of the package importPath, if it belongs to
+// one of the modules of the set.
+func (m *moduleSet) importDir(importPath string) (string, bool) {
+	dir, _, ok := m.resolve(importPath)
+	return dir, ok
+}
+
+// resolve is importDir that also returns the module providing the package.
+func (m *moduleSet) resolve(importPath string) (string, moduleDir, bool) {
+	importPath = strings.TrimSpace(importPath)
+	if m == nil || importPath == "" {
+		return "", moduleDir{}, false
+	}
+	for _, mod := range m.modules {
+		if importPath == mod.path {
+			return mod.dir, mod, true
+		}
+		if strings.HasPrefix(importPath, mod.path+"/") {
+			rel := strings.TrimPrefix(importPath, mod.path+"/")
+			return filepath.Join(mod.dir, filepath.FromSlash(rel)), mod, true
+		}
+	}
+	return "", moduleDir{}, false
+}
+
+// dirsOutside returns the local module directories that are not inside root,
+// i.e. the ones a walk of root does not reach.
+func (m *moduleSet) dirsOutside(root string) []string {
+	if m == nil {
+		return nil
+	}
+	root = absDir("", root)
+	var dirs []string
+	for _, mod := range m.modules {
+		if mod.external {
+			continue
+		}
+		rel, err := filepath.Rel(root, mod.dir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		dirs = append(dirs, mod.dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// findWorkFile locates the go.work file governing dir, honouring GOWORK the way
+// the go command does: "off" disables workspaces and a path selects the file.
+func findWorkFile(dir string) string {
+	switch gowork := os.Getenv("GOWORK"); {
+	case gowork == "off":
+		return ""
+	case gowork != "":
+		return gowork
+	}
+	dir = absDir("", dir)
+	for {
+		candidate := filepath.Join(dir, "go.work")
+		if fileExists(candidate) {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func readWorkFile(path string) *modfile.WorkFile {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	work, err := modfile.ParseWork(path, data, nil)
+	if err != nil {
+		return nil
+	}
+	return work
+}
+
+func readModFile(dir string) *modfile.File {
+	path := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	mod, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return nil
+	}
+	return mod
+}
+
+// localReplaceDir returns the directory of a replace directive pointing to a
+// local path, relative to the directory of the file declaring it.
+func localReplaceDir(fromDir string, r *modfile.Replace) string {
+	if r == nil || r.New.Version != "" || !modfile.IsDirectoryPath(r.New.Path) {
+		return ""
+	}
+	return absDir(fromDir, r.New.Path)
+}
+
+func absDir(base, path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, filepath.FromSlash(path))
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return filepath.Clean(path)
+}
+
+// moduleCacheDir returns GOMODCACHE, defaulting to $GOPATH/pkg/mod like the go
+// command.
+func moduleCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	if paths := filepath.SplitList(build.Default.GOPATH); len(paths) > 0 && paths[0] != "" {
+		return filepath.Join(paths[0], "pkg", "mod")
+	}
+	return ""
+}
+
+// moduleCachePath returns the directory of mod in the module cache, using the
+// cache's case-encoding of upper-case letters.
+func moduleCachePath(cache string, mod module.Version) string {
+	path, err := module.EscapePath(mod.Path)
+	if err != nil {
+		return ""
+	}
+	version, err := module.EscapeVersion(mod.Version)
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cache, filepath.FromSlash(path)+"@"+version)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
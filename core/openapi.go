@@ -21,11 +21,12 @@ const (
 )
 
 type OpenAPI struct {
-	OpenAPI    string                 `json:"openapi"`
-	Info       map[string]interface{} `json:"info"`
-	Paths      map[string]PathItem    `json:"paths"`
-	Components Components             `json:"components,omitempty"`
-	Security   []map[string][]string  `json:"security,omitempty"`
+	OpenAPI    string                   `json:"openapi"`
+	Info       map[string]interface{}   `json:"info"`
+	Servers    []map[string]interface{} `json:"servers,omitempty"`
+	Paths      map[string]PathItem      `json:"paths"`
+	Components Components               `json:"components,omitempty"`
+	Security   []map[string][]string    `json:"security,omitempty"`
 }
 
 // PathItem represents the operations available on a single path.
@@ -45,12 +46,37 @@ type Schema map[string]interface{}
 
 // GenerateOpenAPI builds an OpenAPI JSON spec from route and handler info.
 func GenerateOpenAPI(routes []RouteInfo, handlers map[string]HandlerInfo, types *TypeRegistry, projectName string, enableAuthUI bool) ([]byte, error) {
+	spec, _, err := generateOpenAPI(routes, handlers, types, openAPIOptions{projectName: projectName, enableAuthUI: enableAuthUI})
+	return spec, err
+}
+
+// openAPIOptions carries the ProjectConfig settings that shape the document.
+type openAPIOptions struct {
+	projectName        string
+	enableAuthUI       bool
+	securityMiddleware map[string]string
+	securitySchemes    map[string]map[string]interface{}
+	info               *generalInfo // swag general API annotations, if any
+	embeddedAllOf      bool
+	typeMappings       map[string]map[string]interface{}
+}
+
+// generateOpenAPI is GenerateOpenAPI that also reports the operationIds it had
+// to rename to keep them unique.
+func generateOpenAPI(routes []RouteInfo, handlers map[string]HandlerInfo, types *TypeRegistry, opts openAPIOptions) ([]byte, []Diagnostic, error) {
 	if len(routes) == 0 {
-		return nil, fmt.Errorf("no routes discovered")
+		return nil, nil, fmt.Errorf("no routes discovered")
 	}
 
+	// Optional path parameters are documented as one path per variant.
 	sortedRoutes := make([]RouteInfo, 0, len(routes))
-	sortedRoutes = append(sortedRoutes, routes...)
+	for _, route := range routes {
+		for _, path := range expandOptionalSegments(route.Path) {
+			variant := route
+			variant.Path = path
+			sortedRoutes = append(sortedRoutes, variant)
+		}
+	}
 	sort.Slice(sortedRoutes, func(i, j int) bool {
 		if sortedRoutes[i].Path == sortedRoutes[j].Path {
 			return sortedRoutes[i].Method < sortedRoutes[j].Method
@@ -61,12 +87,24 @@ func GenerateOpenAPI(routes []RouteInfo, handlers map[string]HandlerInfo, types
 	paths := make(map[string]PathItem)
 	components := Components{Schemas: make(map[string]Schema)}
 	builder := newComponentBuilder(types, components.Schemas)
+	builder.embeddedAllOf = opts.embeddedAllOf
+	builder.typeMappings = typeMappingKeys(opts.typeMappings)
+	var operations []operationRef
+	operationIndex := make(map[string]int) // method + path -> index in operations
+	usedSchemes := make(map[string]string) // scheme name -> file of the first operation requiring it
+	routerPaths := annotatedRouterPaths(routes, handlers)
+	var diagnostics []Diagnostic
 
 	for _, route := range sortedRoutes {
 		handler, ok := handlers[route.HandlerID]
 		if !ok {
 			continue
 		}
+		if path, warning := checkRouterAnnotations(route, handler, routerPaths); warning != "" {
+			diagnostics = append(diagnostics, Diagnostic{File: route.File, Message: warning})
+			route.Path = path
+		}
+		handler = withRoutePathParams(handler, route.Path)
 
 		specPath := normalizeOpenAPIPath(route.Path)
 		pathItem := paths[specPath]
@@ -84,8 +122,16 @@ func GenerateOpenAPI(routes []RouteInfo, handlers map[string]HandlerInfo, types
 			}
 		}
 
+		operationID := fmt.Sprintf("%s.%s", handler.Package, handler.Name)
+		if handler.OperationID != "" {
+			operationID = handler.OperationID
+		}
+		if route.RouteName != "" {
+			operationID = route.RouteName
+		}
+
 		operation := Operation{
-			"operationId": fmt.Sprintf("%s.%s", handler.Package, handler.Name),
+			"operationId": operationID,
 			"summary":     summary,
 		}
 		if desc := mergeDescription(handler.Description, handler.Notes); desc != "" {
@@ -113,65 +159,304 @@ func GenerateOpenAPI(routes []RouteInfo, handlers map[string]HandlerInfo, types
 		}
 
 		for _, typeName := range handler.NeededComponents {
+			if mappedTypeSchema(typeName, handler.Package, builder) != nil {
+				continue
+			}
 			builder.ensureComponent(typeName, handler.Package)
 		}
 
 		responses := buildResponses(handler, builder)
 		operation["responses"] = responses
 
+		if handler.Deprecated {
+			operation["deprecated"] = true
+		}
+
+		// @Security annotations win over the schemes of the route's middleware,
+		// which are all required together.
+		if len(handler.Security) > 0 {
+			for _, requirement := range handler.Security {
+				for name := range requirement {
+					useScheme(usedSchemes, name, route.File)
+				}
+			}
+			operation["security"] = handler.Security
+		} else if schemes := routeSecuritySchemes(route, opts.securityMiddleware); len(schemes) > 0 {
+			requirement := make(map[string][]string, len(schemes))
+			for _, name := range schemes {
+				requirement[name] = []string{}
+				useScheme(usedSchemes, name, route.File)
+			}
+			operation["security"] = []map[string][]string{requirement}
+		}
+
 		// Allow opt-out per-operation via handler.NoAuth; if set, explicitly
 		// add an empty security array to override any global security requirement.
 		if handler.NoAuth {
 			operation["security"] = []map[string][]string{}
 		}
 
-		pathItem[strings.ToLower(route.Method)] = operation
+		method := strings.ToLower(route.Method)
+		pathItem[method] = operation
+
+		ref := operationRef{id: operationID, method: method, path: specPath, file: route.File, op: operation}
+		if idx, ok := operationIndex[method+" "+specPath]; ok {
+			operations[idx] = ref
+		} else {
+			operationIndex[method+" "+specPath] = len(operations)
+			operations = append(operations, ref)
+		}
 	}
 
 	if len(paths) == 0 {
-		return nil, fmt.Errorf("no routes with handler metadata available")
+		return nil, nil, fmt.Errorf("no routes with handler metadata available")
 	}
+	diagnostics = append(diagnostics, ensureUniqueOperationIDs(operations)...)
 
+	general := opts.info
+	if general == nil {
+		general = &generalInfo{}
+	}
 	title := "Auto Generated API"
-	if trimmed := strings.TrimSpace(projectName); trimmed != "" {
+	if trimmed := strings.TrimSpace(opts.projectName); trimmed != "" {
 		title = fmt.Sprintf("%s API (Auto Generated)", trimmed)
+	} else if general.title != "" {
+		title = general.title
+	}
+	version := "1.0.0"
+	if general.version != "" {
+		version = general.version
+	}
+	description := fmt.Sprintf("Generated with [%s](%s).", docooName, docooURL)
+	if general.description != "" {
+		description = general.description
 	}
 
 	info := map[string]interface{}{
 		"title":       title,
-		"version":     "1.0.0",
-		"description": fmt.Sprintf("Generated with [%s](%s).", docooName, docooURL),
+		"version":     version,
+		"description": description,
 		"x-generated-by": map[string]interface{}{
 			"name": docooName,
 			"url":  docooURL,
 		},
 	}
+	if general.termsOfService != "" {
+		info["termsOfService"] = general.termsOfService
+	}
+	if len(general.contact) > 0 {
+		info["contact"] = general.contact
+	}
+	if len(general.license) > 0 {
+		info["license"] = general.license
+	}
+
+	// Paths are made relative to @BasePath, which moves into the server URL,
+	// unless some route lies outside it.
+	basePath := strings.TrimSuffix(general.basePath, "/")
+	if stripped, ok := stripBasePath(paths, basePath); ok {
+		paths = stripped
+	} else {
+		basePath = ""
+	}
 
 	doc := OpenAPI{
 		OpenAPI:    "3.0.0",
 		Info:       info,
+		Servers:    general.servers(basePath),
 		Paths:      paths,
 		Components: components,
 	}
 
 	// If enabled, add a Bearer auth security scheme and a global security
 	// requirement that applies to all operations unless an operation
-	// explicitly overrides it (e.g. with an empty `security: []`).
-	if enableAuthUI {
+	// explicitly overrides it (e.g. with an empty `security: []`). Projects
+	// mapping their auth middleware get per-operation requirements instead.
+	if opts.enableAuthUI {
+		useScheme(usedSchemes, "BearerAuth", "")
+		if len(opts.securityMiddleware) == 0 {
+			doc.Security = []map[string][]string{{
+				"BearerAuth": {},
+			}}
+		}
+	}
+	for name := range opts.securitySchemes {
+		useScheme(usedSchemes, name, "")
+	}
+	schemeNames := make([]string, 0, len(usedSchemes))
+	for name := range usedSchemes {
+		schemeNames = append(schemeNames, name)
+	}
+	sort.Strings(schemeNames)
+	for _, name := range schemeNames {
+		scheme, ok := securityScheme(name, opts.securitySchemes)
+		if !ok {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:    usedSchemes[name],
+				Message: fmt.Sprintf("security scheme %q is required but not declared", name),
+			})
+			continue
+		}
 		if doc.Components.SecuritySchemes == nil {
 			doc.Components.SecuritySchemes = make(map[string]map[string]interface{})
 		}
-		doc.Components.SecuritySchemes["BearerAuth"] = map[string]interface{}{
-			"type":         "http",
-			"scheme":       "bearer",
-			"bearerFormat": "JWT",
+		doc.Components.SecuritySchemes[name] = scheme
+	}
+
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+	return spec, diagnostics, nil
+}
+
+func useScheme(used map[string]string, name, file string) {
+	if _, ok := used[name]; !ok {
+		used[name] = file
+	}
+}
+
+// annotatedRouterPaths counts the distinct paths each handler with @Router
+// annotations is registered at, per method.
+func annotatedRouterPaths(routes []RouteInfo, handlers map[string]HandlerInfo) map[string]int {
+	seen := make(map[string]struct{})
+	counts := make(map[string]int)
+	for _, route := range routes {
+		if len(handlers[route.HandlerID].Routers) == 0 {
+			continue
+		}
+		key := route.HandlerID + " " + route.Method
+		if _, dup := seen[key+" "+route.Path]; dup {
+			continue
+		}
+		seen[key+" "+route.Path] = struct{}{}
+		counts[key]++
+	}
+	return counts
+}
+
+// checkRouterAnnotations cross-checks route against the handler's @Router
+// lines. A line for the route's method whose path is neither the route's path
+// nor a suffix of it (the rest being a base path) is reported; it replaces the
+// discovered path when the handler is registered only once for that method.
+// It returns the path to document and a warning, empty when they agree.
+func checkRouterAnnotations(route RouteInfo, handler HandlerInfo, registered map[string]int) (string, string) {
+	if len(handler.Routers) == 0 {
+		return route.Path, ""
+	}
+	specPath := normalizeOpenAPIPath(route.Path)
+	var candidates []string
+	for _, r := range handler.Routers {
+		if !strings.EqualFold(r.Method, route.Method) {
+			continue
+		}
+		annotated := normalizeOpenAPIPath(r.Path)
+		if annotated == specPath || (annotated != "/" && strings.HasSuffix(specPath, annotated)) {
+			return route.Path, ""
+		}
+		candidates = append(candidates, annotated)
+	}
+	if len(candidates) == 0 {
+		return route.Path, fmt.Sprintf("%s %s: no @Router line of %s matches this route", route.Method, specPath, handler.Name)
+	}
+	if len(candidates) == 1 && registered[route.HandlerID+" "+route.Method] == 1 {
+		return candidates[0], fmt.Sprintf("%s %s: @Router of %s documents %s; using it", route.Method, specPath, handler.Name, candidates[0])
+	}
+	return route.Path, fmt.Sprintf("%s %s: @Router of %s documents %s", route.Method, specPath, handler.Name, strings.Join(candidates, ", "))
+}
+
+// defaultSecuritySchemes are the schemes that need no declaration in
+// ProjectConfig.SecuritySchemes.
+var defaultSecuritySchemes = map[string]map[string]interface{}{
+	"BearerAuth": {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+	"BasicAuth":  {"type": "http", "scheme": "basic"},
+}
+
+// securityScheme returns the definition of the scheme called name, preferring
+// the declared ones over the defaults.
+func securityScheme(name string, declared map[string]map[string]interface{}) (map[string]interface{}, bool) {
+	if scheme, ok := declared[name]; ok {
+		return scheme, true
+	}
+	scheme, ok := defaultSecuritySchemes[name]
+	return scheme, ok
+}
+
+// routeSecuritySchemes maps the middleware guarding route to security schemes.
+// A key of middleware matches the middleware as written (jwtware.New) or its
+// final name, so requireAuth also matches auth.requireAuth.
+func routeSecuritySchemes(route RouteInfo, middleware map[string]string) []string {
+	if len(middleware) == 0 {
+		return nil
+	}
+	var schemes []string
+	for _, id := range route.Middleware {
+		name, ok := middleware[id]
+		if !ok {
+			if dot := strings.LastIndex(id, "."); dot >= 0 {
+				name, ok = middleware[id[dot+1:]]
+			}
+		}
+		if ok && strings.TrimSpace(name) != "" {
+			schemes = append(schemes, strings.TrimSpace(name))
+		}
+	}
+	return uniqueStrings(schemes)
+}
+
+// operationRef locates a generated operation for the operationId checks.
+type operationRef struct {
+	id     string
+	method string
+	path   string
+	file   string
+	op     Operation
+}
+
+// ensureUniqueOperationIDs renames every operation whose operationId is shared
+// with another to <id>_<method><Path> (streaming.dynamicHandler_getDynamicBeta),
+// so that an operation's new name does not depend on the other routes, and
+// reports one diagnostic per collision.
+func ensureUniqueOperationIDs(operations []operationRef) []Diagnostic {
+	byID := make(map[string][]int)
+	taken := make(map[string]struct{})
+	var ids []string
+	for i, ref := range operations {
+		if _, seen := byID[ref.id]; !seen {
+			ids = append(ids, ref.id)
 		}
-		doc.Security = []map[string][]string{{
-			"BearerAuth": {},
-		}}
+		byID[ref.id] = append(byID[ref.id], i)
+		taken[ref.id] = struct{}{}
 	}
 
-	return json.MarshalIndent(doc, "", "  ")
+	var diagnostics []Diagnostic
+	for _, id := range ids {
+		shared := byID[id]
+		if len(shared) < 2 {
+			continue
+		}
+		var used, renamed []string
+		for _, i := range shared {
+			ref := operations[i]
+			base := id + "_" + inlineHandlerName(ref.method, ref.path)
+			unique := base
+			for n := 2; ; n++ {
+				if _, dup := taken[unique]; !dup {
+					break
+				}
+				unique = base + strconv.Itoa(n)
+			}
+			taken[unique] = struct{}{}
+			ref.op["operationId"] = unique
+			used = append(used, strings.ToUpper(ref.method)+" "+ref.path)
+			renamed = append(renamed, unique)
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			File:    operations[shared[0]].file,
+			Message: fmt.Sprintf("operationId %q is used by %s; renamed to %s", id, strings.Join(used, ", "), strings.Join(renamed, ", ")),
+		})
+	}
+	return diagnostics
 }
 
 func deriveDefaultSummary(handler HandlerInfo, route RouteInfo) string {
@@ -246,7 +531,7 @@ func trimSuffixCI(s, suffix string) string {
 }
 
 func extractBaseFromHandlerExpr(expr string) string {
-	expr = strings.TrimSpace(expr)
+	expr = trimCallArgs(strings.TrimSpace(expr))
 	if expr == "" {
 		return ""
 	}
@@ -260,6 +545,27 @@ func extractBaseFromHandlerExpr(expr string) string {
 	return camelizeIdentifier(candidate)
 }
 
+// trimCallArgs drops the arguments of a trailing call, so that the factory
+// call users.List(svc) is named like the users.List handler.
+func trimCallArgs(expr string) string {
+	if !strings.HasSuffix(expr, ")") {
+		return expr
+	}
+	depth := 0
+	for i := len(expr) - 1; i >= 0; i-- {
+		switch expr[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(expr[:i])
+			}
+		}
+	}
+	return expr
+}
+
 func camelizeIdentifier(input string) string {
 	input = strings.TrimSpace(input)
 	if input == "" {
@@ -431,12 +737,22 @@ func buildResponses(handler HandlerInfo, builder *componentBuilder) map[string]i
 		resp := map[string]interface{}{
 			"description": statusDescription(status),
 		}
+		if desc := handler.ResponseDescriptions[status]; desc != "" {
+			resp["description"] = desc
+		}
+		if headers := responseHeaders(handler, status, builder); len(headers) > 0 {
+			resp["headers"] = headers
+		}
 		if handler.EmptyBodyStatus != nil && handler.EmptyBodyStatus[status] {
 			responses[status] = resp
 			continue
 		}
 		contentType := pickFirst(handler.Produces, "application/json")
-		if handler.ResponseSchemas != nil {
+		_, annotated := handler.annotated[status]
+		if media := handler.responseMedia[status]; media != "" && !annotated {
+			contentType = media
+		}
+		if handler.ResponseSchemas != nil && !annotated {
 			if explicit, ok := handler.ResponseSchemas[status]; ok && explicit != nil {
 				resp["content"] = map[string]interface{}{
 					contentType: map[string]interface{}{
@@ -461,6 +777,24 @@ func buildResponses(handler HandlerInfo, builder *componentBuilder) map[string]i
 	return responses
 }
 
+// responseHeaders builds the headers documented with @Header for status,
+// including those given for "all" statuses.
+func responseHeaders(handler HandlerInfo, status string, builder *componentBuilder) map[string]interface{} {
+	entries := append(append([]ResponseHeader(nil), handler.ResponseHeaders["all"]...), handler.ResponseHeaders[status]...)
+	if len(entries) == 0 {
+		return nil
+	}
+	headers := make(map[string]interface{}, len(entries))
+	for _, h := range entries {
+		header := map[string]interface{}{"schema": schemaOrRef(h.Type, handler.Package, builder)}
+		if h.Description != "" {
+			header["description"] = h.Description
+		}
+		headers[h.Name] = header
+	}
+	return headers
+}
+
 func mergeDescription(description string, notes []string) string {
 	desc := strings.TrimSpace(description)
 	if len(notes) == 0 {
@@ -510,6 +844,8 @@ func buildParameters(handler HandlerInfo, builder *componentBuilder) []map[strin
 			schema = map[string]interface{}{"type": "string"}
 		}
 
+		applyParameterConstraints(schema, p)
+
 		param := map[string]interface{}{
 			"name":     p.Name,
 			"in":       p.In,
@@ -524,6 +860,54 @@ func buildParameters(handler HandlerInfo, builder *componentBuilder) []map[strin
 	return params
 }
 
+// applyParameterConstraints copies the format, pattern and bounds of p onto an
+// inline schema; referenced schemas are left alone.
+func applyParameterConstraints(schema map[string]interface{}, p Parameter) {
+	if _, isRef := schema["$ref"]; isRef {
+		return
+	}
+	if p.Format != "" {
+		schema["format"] = p.Format
+	}
+	if p.Pattern != "" {
+		schema["pattern"] = p.Pattern
+	}
+	if p.Minimum != nil {
+		schema["minimum"] = *p.Minimum
+	}
+	if p.Maximum != nil {
+		schema["maximum"] = *p.Maximum
+	}
+	if p.MinLength != nil {
+		schema["minLength"] = *p.MinLength
+	}
+	if p.MaxLength != nil {
+		schema["maxLength"] = *p.MaxLength
+	}
+	// Enums of array parameters constrain the items.
+	target := schema
+	if items, ok := schema["items"].(map[string]interface{}); ok && len(p.Enum) > 0 {
+		if _, isRef := items["$ref"]; !isRef {
+			target = items
+		}
+	}
+	if len(p.Enum) > 0 {
+		itemType, _ := target["type"].(string)
+		values := make([]interface{}, 0, len(p.Enum))
+		for _, v := range p.Enum {
+			values = append(values, annotationValue(v, itemType))
+		}
+		target["enum"] = values
+	}
+	schemaType, _ := schema["type"].(string)
+	if p.Default != "" {
+		schema["default"] = annotationValue(p.Default, schemaType)
+	}
+	if p.Example != "" {
+		schema["example"] = annotationValue(p.Example, schemaType)
+	}
+}
+
 func schemaOrRef(typeName, pkg string, builder *componentBuilder) map[string]interface{} {
 	typeName = strings.TrimSpace(typeName)
 	if typeName == "" {
@@ -568,18 +952,28 @@ func schemaOrRef(typeName, pkg string, builder *componentBuilder) map[string]int
 		return map[string]interface{}{"type": "object"}
 	}
 
+	if base, fields, ok := splitCompositeType(typeName); ok {
+		return schemaFromCompositeType(base, fields, pkg, builder)
+	}
+
+	if schema := mappedTypeSchema(typeName, pkg, builder); schema != nil {
+		return schema
+	}
+
 	lower := strings.ToLower(typeName)
 	switch lower {
 	case "string":
 		return map[string]interface{}{"type": "string"}
 	case "bool", "boolean":
 		return map[string]interface{}{"type": "boolean"}
-	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "integer":
 		return map[string]interface{}{"type": "integer"}
-	case "float32", "float64":
+	case "float32", "float64", "number":
 		return map[string]interface{}{"type": "number"}
-	case "interface{}", "map[string]interface{}", "fiber.map":
+	case "interface{}", "any", "map[string]interface{}", "map[string]any", "fiber.map", "object":
 		return map[string]interface{}{"type": "object"}
+	case "file":
+		return map[string]interface{}{"type": "string", "format": "binary"}
 	case "time.time":
 		return map[string]interface{}{"type": "string", "format": "date-time"}
 	case "[]byte":
@@ -596,6 +990,59 @@ func schemaOrRef(typeName, pkg string, builder *componentBuilder) map[string]int
 	}
 }
 
+// splitCompositeType splits swag's composite notation response.Envelope{data=User,meta=Meta}
+// into the base type and its field overrides.
+func splitCompositeType(typeName string) (string, [][2]string, bool) {
+	open := strings.Index(typeName, "{")
+	if open <= 0 || !strings.HasSuffix(typeName, "}") || !strings.Contains(typeName[open:], "=") {
+		return "", nil, false
+	}
+	var fields [][2]string
+	for _, part := range splitTypeList(typeName[open+1 : len(typeName)-1]) {
+		name, typ, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return "", nil, false
+		}
+		fields = append(fields, [2]string{strings.TrimSpace(name), strings.TrimSpace(typ)})
+	}
+	return strings.TrimSpace(typeName[:open]), fields, len(fields) > 0
+}
+
+// splitTypeList splits s on commas outside braces and brackets.
+func splitTypeList(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// schemaFromCompositeType documents a composite as the base type combined
+// with an object replacing the overridden fields, as swag does.
+func schemaFromCompositeType(base string, fields [][2]string, pkg string, builder *componentBuilder) map[string]interface{} {
+	props := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		props[field[0]] = schemaOrRef(field[1], pkg, builder)
+	}
+	return map[string]interface{}{
+		"allOf": []interface{}{
+			schemaOrRef(base, pkg, builder),
+			map[string]interface{}{"type": "object", "properties": props},
+		},
+	}
+}
+
 func schemaFromInlineStruct(typeExpr, pkg string, builder *componentBuilder) map[string]interface{} {
 	expr, err := parser.ParseExpr(typeExpr)
 	if err != nil {
@@ -613,42 +1060,12 @@ func schemaFromStructType(structType *ast.StructType, pkg string, builder *compo
 		return map[string]interface{}{"type": "object"}
 	}
 
-	props := make(map[string]interface{})
-	var required []string
-
-	for _, field := range structType.Fields.List {
-		if len(field.Names) == 0 {
-			continue
-		}
-		for _, name := range field.Names {
-			if name == nil || name.Name == "" {
-				continue
-			}
-			meta := extractJSONMetadata(field, name.Name)
-			if meta.skip || meta.name == "" {
-				continue
-			}
-			fieldSchema := schemaForStructField(field.Type, pkg, builder)
-			if fieldSchema == nil {
-				fieldSchema = map[string]interface{}{"type": "string"}
-			}
-			props[meta.name] = fieldSchema
-			optional := meta.omitEmpty || isPointerType(field.Type)
-			if !optional {
-				required = append(required, meta.name)
-			}
+	return structObjectSchema(structType, pkg, builder, func(expr ast.Expr, pkg string) map[string]interface{} {
+		if schema := schemaForStructField(expr, pkg, builder); schema != nil {
+			return schema
 		}
-	}
-
-	schema := map[string]interface{}{"type": "object"}
-	if len(props) > 0 {
-		schema["properties"] = props
-	}
-	if len(required) > 0 {
-		sort.Strings(required)
-		schema["required"] = required
-	}
-	return schema
+		return map[string]interface{}{"type": "string"}
+	})
 }
 
 func schemaForStructField(expr ast.Expr, pkg string, builder *componentBuilder) map[string]interface{} {
@@ -669,23 +1086,14 @@ func normalizeOpenAPIPath(path string) string {
 			continue
 		}
 		trimmed := strings.TrimSpace(segment)
+		rp, ok := parseRouteParam(trimmed)
 		switch {
-		case strings.HasPrefix(trimmed, ":"):
-			name := strings.TrimPrefix(trimmed, ":")
-			name = strings.TrimSpace(name)
-			if name == "" {
-				name = "param"
-			}
-			segments[i] = "{" + name + "}"
-		case strings.HasPrefix(trimmed, "*"):
-			name := strings.TrimPrefix(trimmed, "*")
-			name = strings.TrimSpace(name)
-			if name == "" {
-				name = "wildcard"
-			}
-			segments[i] = "{" + name + "}"
-		default:
+		case !ok:
 			segments[i] = trimmed
+		case rp.name == "":
+			segments[i] = "{param}"
+		default:
+			segments[i] = "{" + rp.name + "}"
 		}
 	}
 	result := strings.Join(segments, "/")
@@ -761,9 +1169,11 @@ func canonicalContentType(value, fallback string) string {
 
 // componentBuilder coordinates schema construction for named types.
 type componentBuilder struct {
-	registry   *TypeRegistry
-	components map[string]Schema
-	building   map[string]struct{}
+	registry      *TypeRegistry
+	components    map[string]Schema
+	building      map[string]struct{}
+	embeddedAllOf bool                              // compose embedded structs with allOf instead of flattening them
+	typeMappings  map[string]map[string]interface{} // ProjectConfig.TypeMappings by qualified type name
 }
 
 func newComponentBuilder(reg *TypeRegistry, components map[string]Schema) *componentBuilder {
@@ -779,7 +1189,7 @@ func (b *componentBuilder) ensureComponent(typeName, pkg string) string {
 	if !strings.Contains(typeName, ".") && pkg != "" {
 		qual = pkg + "." + typeName
 	}
-	compName := buildComponentName(qual)
+	compName := b.registry.componentName(qual)
 	if b.components == nil {
 		return compName
 	}
@@ -805,7 +1215,7 @@ func (b *componentBuilder) ensureComponent(typeName, pkg string) string {
 	schema := Schema{"type": "object"}
 	if spec != nil {
 		if built, ok := b.buildSchemaFromSpec(spec); ok && built != nil {
-			schema = built
+			schema = describeSchema(built, b.registry.typeDoc(spec.Spec))
 		}
 	}
 
@@ -821,40 +1231,7 @@ func (b *componentBuilder) buildSchemaFromSpec(info *TypeSpecInfo) (Schema, bool
 
 	switch t := info.Spec.Type.(type) {
 	case *ast.StructType:
-		props := make(map[string]interface{})
-		var required []string
-		for _, field := range t.Fields.List {
-			if len(field.Names) == 0 {
-				continue
-			}
-			for _, name := range field.Names {
-				if name == nil || name.Name == "" {
-					continue
-				}
-				meta := extractJSONMetadata(field, name.Name)
-				if meta.skip || meta.name == "" {
-					continue
-				}
-				schema := b.schemaFromExpr(field.Type, info.Package)
-				if schema == nil {
-					continue
-				}
-				props[meta.name] = schema
-				optional := meta.omitEmpty || isPointerType(field.Type)
-				if !optional {
-					required = append(required, meta.name)
-				}
-			}
-		}
-		schema := Schema{"type": "object"}
-		if len(props) > 0 {
-			schema["properties"] = props
-		}
-		if len(required) > 0 {
-			sort.Strings(required)
-			schema["required"] = required
-		}
-		return schema, true
+		return structObjectSchema(t, info.Package, b, b.schemaFromExpr), true
 	case *ast.ArrayType:
 		items := b.schemaFromExpr(t.Elt, info.Package)
 		if items == nil {
@@ -879,7 +1256,11 @@ func (b *componentBuilder) buildSchemaFromSpec(info *TypeSpecInfo) (Schema, bool
 		if info.Spec.Assign != token.NoPos {
 			return b.schemaFromExpr(info.Spec.Type, info.Package), true
 		}
-		return b.schemaFromExpr(info.Spec.Type, info.Package), true
+		schema := Schema(b.schemaFromExpr(info.Spec.Type, info.Package))
+		if values := b.registry.enumValues(info); len(values) > 0 {
+			applyEnumValues(schema, values)
+		}
+		return schema, true
 	}
 }
 
@@ -887,6 +1268,9 @@ func (b *componentBuilder) schemaFromExpr(expr ast.Expr, pkg string) map[string]
 	if expr == nil {
 		return map[string]interface{}{"type": "object"}
 	}
+	if typ := b.registry.typedExprType(expr); typ != "" {
+		return schemaOrRef(typ, pkg, b)
+	}
 	return schemaOrRef(exprToString(expr), pkg, b)
 }
 
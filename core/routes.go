@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/printer"
@@ -11,18 +12,40 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // RouteInfo stores details about a Fiber route discovered in a Register method.
 type RouteInfo struct {
-	Method            string // HTTP verb, e.g. GET, POST
-	Path              string // unquoted route path
-	Package           string // Go package name
-	File              string // absolute or relative file path where route was declared
-	HandlerExpr       string // raw expression passed to router (e.g. h.syncFromUpstream)
-	HandlerName       string // extracted function/method identifier (e.g. syncFromUpstream)
-	HandlerID         string // stable identifier (file + handler name)
-	HandlerImportPath string // fully qualified import path when handler lives in another package
+	Method            string   // HTTP verb, e.g. GET, POST
+	Path              string   // unquoted route path
+	Package           string   // Go package name
+	File              string   // absolute or relative file path where route was declared
+	HandlerExpr       string   // raw expression passed to router (e.g. h.syncFromUpstream)
+	HandlerName       string   // extracted function/method identifier (e.g. syncFromUpstream)
+	HandlerID         string   // stable identifier (file + handler name)
+	HandlerImportPath string   // fully qualified import path when handler lives in another package
+	RouteName         string   // name given with Fiber's .Name("users.get"), used as the operationId
+	HandlerFactory    bool     // handler is the closure returned by calling HandlerName, e.g. users.List(svc)
+	Middleware        []string // middleware guarding the route as written, e.g. jwtware.New, requireAuth
+
+	pos token.Pos // position of the registration in File, to order it against Use calls
+}
+
+// Diagnostic reports a call that looked like a route registration but was not
+// documented, such as cache.Get("key", &dst) on a value that is not a router.
+type Diagnostic struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
 }
 
 var httpVerbs = map[string]struct{}{
@@ -39,26 +62,49 @@ var httpVerbs = map[string]struct{}{
 
 var printerFset = token.NewFileSet()
 
-// FindRoutes walks a file or directory tree and extracts Fiber routes from Register methods.
+// FindRoutes walks a file or directory tree and extracts routes using every
+// registered framework.
 func FindRoutes(path string) ([]RouteInfo, error) {
+	routes, _, err := findRoutes(path, registeredFrameworks())
+	return routes, err
+}
+
+// FindRoutesWithDiagnostics is FindRoutes that also reports the route-like calls
+// it skipped, e.g. because the receiver is not a known router.
+func FindRoutesWithDiagnostics(path string) ([]RouteInfo, []Diagnostic, error) {
+	return findRoutes(path, registeredFrameworks())
+}
+
+func findRoutes(path string, frameworks []Framework) ([]RouteInfo, []Diagnostic, error) {
 	info, err := os.Stat(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	disc := newDiscovery(frameworks)
+	var (
+		routes      []RouteInfo
+		diagnostics []Diagnostic
+	)
 	if info.IsDir() {
-		return findRoutesInDir(path)
+		routes, diagnostics, err = findRoutesInDir(path, disc)
+	} else {
+		routes, diagnostics, err = findRoutesInFile(path, disc)
 	}
-
-	routes, err := findRoutesInFile(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return routes, nil
+	resolve := prefixResolver(disc.edges)
+	routes = resolveRoutePrefixes(routes, resolve, disc.groupGuards)
+	applyMiddlewareGuards(routes, disc.guards, resolve)
+	return routes, diagnostics, nil
 }
 
-func findRoutesInDir(root string) ([]RouteInfo, error) {
-	var routes []RouteInfo
+func findRoutesInDir(root string, disc *discovery) ([]RouteInfo, []Diagnostic, error) {
+	var (
+		routes      []RouteInfo
+		diagnostics []Diagnostic
+	)
 	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -72,31 +118,53 @@ func findRoutesInDir(root string) ([]RouteInfo, error) {
 		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
 			return nil
 		}
-		fileRoutes, err := findRoutesInFile(path)
+		fileRoutes, fileDiagnostics, err := findRoutesInFile(path, disc)
 		if err != nil {
 			return err
 		}
 		routes = append(routes, fileRoutes...)
+		diagnostics = append(diagnostics, fileDiagnostics...)
 		return nil
 	})
 	if walkErr != nil {
-		return nil, walkErr
+		return nil, nil, walkErr
 	}
-	return routes, nil
+	return routes, diagnostics, nil
 }
 
-func findRoutesInFile(path string) ([]RouteInfo, error) {
+func findRoutesInFile(path string, disc *discovery) ([]RouteInfo, []Diagnostic, error) {
 	fset := token.NewFileSet()
 	fileNode, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
 	if err != nil {
-		return nil, err
-	}
+		return nil, nil, err
+	}
+
+	importAliases := fileImportAliases(fileNode)
+	scanner := &routeScanner{
+		fset:            fset,
+		file:            fileNode,
+		path:            path,
+		imports:         importAliases,
+		bindings:        make(map[string]string),
+		frameworks:      disc.frameworks,
+		finders:         routeFindersFor(disc.frameworks, importAliases),
+		groupMiddleware: importsGroupMiddleware(disc.frameworks, importAliases),
+		mounted:         make(map[string]struct{}),
+		discovery:       disc,
+		consts:          disc.consts,
+		localConsts:     make(map[string]constDecl),
+		origins:         make(map[string]string),
+		routeNames:      make(map[*ast.CallExpr]string),
+	}
+	routes := scanner.scan()
+	return routes, scanner.diagnostics, nil
+}
 
-	prefixes := make(map[string]string)
-	handlerBindings := make(map[string]string)
-	var routes []RouteInfo
-	importAliases := make(map[string]string)
-	for _, imp := range fileNode.Imports {
+// fileImportAliases maps the identifiers a file refers to its imports by to
+// their import paths.
+func fileImportAliases(file *ast.File) map[string]string {
+	aliases := make(map[string]string)
+	for _, imp := range file.Imports {
 		alias := ""
 		if imp.Name != nil {
 			alias = strings.TrimSpace(imp.Name.Name)
@@ -106,33 +174,150 @@ func findRoutesInFile(path string) ([]RouteInfo, error) {
 			continue
 		}
 		if alias == "" {
-			alias = stdpath.Base(importPath)
+			alias = defaultImportAlias(importPath)
 		}
 		if alias != "" && alias != "." && alias != "_" {
-			importAliases[alias] = importPath
+			aliases[alias] = importPath
 		}
 	}
+	return aliases
+}
+
+// routeScanner collects route registrations from a single parsed file.
+type routeScanner struct {
+	fset            *token.FileSet
+	file            *ast.File
+	path            string
+	imports         map[string]string
+	bindings        map[string]string
+	frameworks      []Framework
+	finders         []RouteFinder            // route finders ordered by the frameworks this file imports
+	groupMiddleware bool                     // the file imports a framework with GroupMiddleware
+	funcs           map[string]*ast.FuncDecl // top-level functions, used to follow Mount targets
+	mounted         map[string]struct{}      // functions walked through a Mount call instead of at top level
+	pkg             *packageRouters          // routers declared at the top level of the file's package
+	routers         map[string]struct{}      // router variables visible in the function being walked
+	varTypes        map[string]string        // variables of the function being walked -> their package struct type
+	recvTypes       map[string]string        // variables of the function being walked -> typeKey of their type
+	discovery       *discovery
+	consts          *constCache
+	localConsts     map[string]constDecl     // constants declared in the function being walked
+	origins         map[string]string        // variables assigned from calls -> called function, see funcKey
+	routeNames      map[*ast.CallExpr]string // route registrations named with a chained .Name(...)
+	scope           ast.Node                 // function body or router closure being walked
+	inClosure       bool                     // scope is a router closure such as r.Group(func(r chi.Router) {...})
+	routes          []RouteInfo
+	diagnostics     []Diagnostic
+}
+
+func (s *routeScanner) scan() []RouteInfo {
+	s.funcs = topLevelFuncs(s.file)
+	for name := range collectMountedFuncs(s.file, s.stringValue) {
+		if _, ok := s.funcs[name]; ok {
+			s.mounted[name] = struct{}{}
+		}
+	}
+	s.pkg = s.discovery.packageRouters(s.path, s.file)
+	s.routers = make(map[string]struct{}, len(s.pkg.vars))
+	for name := range s.pkg.vars {
+		s.routers[name] = struct{}{}
+	}
+	s.varTypes = make(map[string]string, len(s.pkg.varTypes))
+	for name, typ := range s.pkg.varTypes {
+		s.varTypes[name] = typ
+	}
+	s.recvTypes = make(map[string]string, len(s.pkg.recvTypes))
+	for name, key := range s.pkg.recvTypes {
+		s.recvTypes[name] = key
+	}
+	s.walkScope(s.file, make(map[string]string))
+	return s.routes
+}
+
+// topLevelFuncs indexes the functions (not methods) declared in file by name.
+func topLevelFuncs(file *ast.File) map[string]*ast.FuncDecl {
+	funcs := make(map[string]*ast.FuncDecl)
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name != nil {
+			funcs[fn.Name.Name] = fn
+		}
+	}
+	return funcs
+}
+
+// diagnose records a skipped route candidate, or a part of a route that is not
+// documented, at the position of node.
+func (s *routeScanner) diagnose(node ast.Node, format string, args ...any) {
+	pos := s.fset.Position(node.Pos())
+	s.diagnostics = append(s.diagnostics, Diagnostic{
+		File:    s.path,
+		Line:    pos.Line,
+		Column:  pos.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
 
-	ast.Inspect(fileNode, func(n ast.Node) bool {
+// walkScope scans node twice: the first pass only records group and mount prefixes
+// so that routes registered before a Mount call still receive the mount prefix.
+func (s *routeScanner) walkScope(node ast.Node, prefixes map[string]string) {
+	outer := s.scope
+	s.scope = node
+	defer func() { s.scope = outer }()
+	s.walk(node, prefixes, false)
+	s.walk(node, prefixes, true)
+}
+
+func (s *routeScanner) walk(root ast.Node, prefixes map[string]string, emit bool) {
+	ast.Inspect(root, func(n ast.Node) bool {
 		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if _, ok := s.mounted[node.Name.Name]; ok || !emit || node.Body == nil {
+				return false
+			}
+			s.enterFunc(node)
+			s.walkScope(node.Body, s.funcPrefixes(node, prefixes))
+			return false
+		case *ast.FuncLit:
+			s.bindRouterParams(node.Type.Params, false)
+		case *ast.DeclStmt:
+			s.trackLocalConsts(node)
 		case *ast.AssignStmt:
-			trackHandlerAssign(handlerBindings, node, importAliases)
-			handleGroupAssign(prefixes, node)
+			trackHandlerAssign(s.bindings, node, s.imports)
+			handleGroupAssign(prefixes, node, s.stringValue)
+			s.bindGroupMiddlewareAssign(node, prefixes)
+			s.trackRouterAssign(node)
+			s.trackRouterOrigin(node)
 		case *ast.ValueSpec:
-			trackHandlerValueSpec(handlerBindings, node, importAliases)
-			handleGroupValueSpec(prefixes, node)
+			trackHandlerValueSpec(s.bindings, node, s.imports)
+			handleGroupValueSpec(prefixes, node, s.stringValue)
+			s.trackRouterValueSpec(node)
 		case *ast.CallExpr:
-			if route, ok := extractRouteFromCall(node, fileNode, path, prefixes, importAliases, handlerBindings); ok {
-				routes = append(routes, route)
+			if body, scope, ok := s.scopedRouterCall(node, prefixes); ok {
+				s.bindClosureRouters(node)
+				if emit {
+					inClosure := s.inClosure
+					s.inClosure = true
+					s.walkScope(body, scope)
+					s.inClosure = inClosure
+				}
+				return false
+			}
+			s.handleMount(node, prefixes, emit)
+			if !emit {
+				return true
+			}
+			s.recordRouterArgs(node, prefixes)
+			s.trackRouteName(node)
+			s.trackMiddleware(node, prefixes)
+			if route, ok := s.extractRouteFromCall(node, prefixes); ok {
+				s.routes = append(s.routes, route)
 			}
 		}
 		return true
 	})
-
-	return routes, nil
 }
 
-func handleGroupAssign(prefixes map[string]string, stmt *ast.AssignStmt) {
+func handleGroupAssign(prefixes map[string]string, stmt *ast.AssignStmt, str stringResolver) {
 	if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
 		return
 	}
@@ -144,12 +329,12 @@ func handleGroupAssign(prefixes map[string]string, stmt *ast.AssignStmt) {
 	if !ok || ident.Name == "" {
 		return
 	}
-	if prefix, ok := groupPrefixFromCall(prefixes, call); ok {
+	if prefix, ok := groupPrefixFromCall(prefixes, call, str); ok {
 		prefixes[ident.Name] = prefix
 	}
 }
 
-func handleGroupValueSpec(prefixes map[string]string, spec *ast.ValueSpec) {
+func handleGroupValueSpec(prefixes map[string]string, spec *ast.ValueSpec, str stringResolver) {
 	if len(spec.Names) != 1 || len(spec.Values) != 1 {
 		return
 	}
@@ -161,22 +346,27 @@ func handleGroupValueSpec(prefixes map[string]string, spec *ast.ValueSpec) {
 	if ident == nil || ident.Name == "" {
 		return
 	}
-	if prefix, ok := groupPrefixFromCall(prefixes, call); ok {
+	if prefix, ok := groupPrefixFromCall(prefixes, call, str); ok {
 		prefixes[ident.Name] = prefix
 	}
 }
 
-func groupPrefixFromCall(prefixes map[string]string, call *ast.CallExpr) (string, bool) {
+func groupPrefixFromCall(prefixes map[string]string, call *ast.CallExpr, str stringResolver) (string, bool) {
 	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok || sel.Sel == nil || sel.Sel.Name != "Group" {
+	if !ok || sel.Sel == nil {
 		return "", false
 	}
-	basePrefix := computePrefix(prefixes, sel.X)
+	if sel.Sel.Name == "With" {
+		// chi: r.With(mw) returns an inline router sharing the receiver's prefix.
+		return computePrefix(prefixes, sel.X, str), true
+	}
+	if sel.Sel.Name != "Group" {
+		return "", false
+	}
+	basePrefix := computePrefix(prefixes, sel.X, str)
 	if len(call.Args) > 0 {
-		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-			if val, err := strconv.Unquote(lit.Value); err == nil {
-				basePrefix = joinRoutePath(basePrefix, val)
-			}
+		if val, ok := str(call.Args[0]); ok {
+			basePrefix = joinRoutePath(basePrefix, val)
 		}
 	}
 	return basePrefix, true
@@ -247,63 +437,155 @@ func importPathFromExpr(expr ast.Expr, imports map[string]string, bindings map[s
 	return ""
 }
 
-func extractRouteFromCall(call *ast.CallExpr, file *ast.File, filePath string, prefixes map[string]string, imports, bindings map[string]string) (RouteInfo, bool) {
-	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok || sel.Sel == nil {
+// extractRouteFromCall matches call against the file's route finders. Matches
+// whose receiver is not a known router, or whose handler cannot be resolved,
+// are recorded as diagnostics instead of routes.
+func (s *routeScanner) extractRouteFromCall(call *ast.CallExpr, prefixes map[string]string) (RouteInfo, bool) {
+	var (
+		rc      RouteCall
+		matched bool
+	)
+	for _, finder := range s.finders {
+		if rf, ok := finder.(resolvingRouteFinder); ok {
+			rc, matched = rf.matchRouteResolved(call, s.stringValue)
+		} else {
+			rc, matched = finder.MatchRoute(call)
+		}
+		if matched {
+			break
+		}
+	}
+	if !matched {
 		return RouteInfo{}, false
 	}
 
-	methodName := sel.Sel.Name
-	switch {
-	case hasVerb(methodName):
-		methodName = methodName
-	case hasVerb(strings.Title(methodName)):
-		methodName = strings.Title(methodName)
-	case hasVerb(strings.ToUpper(methodName)):
-		methodName = strings.ToUpper(methodName)
-	default:
+	if !s.isRouterExpr(rc.Receiver) {
+		s.diagnose(call, "skipped %s %q: receiver %s is not a known router", rc.Method, rc.Path, exprToString(rc.Receiver))
 		return RouteInfo{}, false
 	}
-	method := strings.ToUpper(methodName)
 
-	if len(call.Args) == 0 {
+	if rc.Method != strings.ToUpper(rc.Method) {
+		// ServeMux compares methods case-sensitively, so "post /users" never
+		// matches a POST request.
+		s.diagnose(call, "skipped %s %q: method %s is not upper case", rc.Method, rc.Path, rc.Method)
 		return RouteInfo{}, false
 	}
-	pathLit, ok := call.Args[0].(*ast.BasicLit)
-	if !ok || pathLit.Kind != token.STRING {
-		return RouteInfo{}, false
+
+	if rc.host != "" {
+		s.diagnose(call, "%s %q: host %s is not documented", rc.Method, rc.Path, rc.host)
 	}
-	pathValue, err := strconv.Unquote(pathLit.Value)
-	if err != nil {
-		return RouteInfo{}, false
+
+	prefix := computePrefix(prefixes, rc.Receiver, s.stringValue)
+	fullPath := joinRoutePath(prefix, rc.Path)
+
+	if lit, ok := rc.Handler.(*ast.FuncLit); ok {
+		return RouteInfo{
+			Method:      rc.Method,
+			Path:        fullPath,
+			Package:     s.file.Name.Name,
+			File:        s.path,
+			HandlerName: inlineHandlerName(rc.Method, withoutParamPrefix(fullPath)),
+			HandlerID:   inlineHandlerID(s.path, s.fset.Position(lit.Pos()).Line),
+			RouteName:   s.routeNames[call],
+			Middleware:  s.routeMiddleware(rc),
+			pos:         call.Pos(),
+		}, true
 	}
 
-	handlerExpr, handlerName, handlerImport := handlerInfoFromCall(call, imports, bindings)
+	handlerExpr, handlerName, handlerImport := handlerInfoFromExpr(rc.Handler, s.imports, s.bindings)
+	factory := false
+	if call, ok := rc.Handler.(*ast.CallExpr); ok && unwrapHandlerConversion(call) == nil {
+		factory = true
+	}
+	if factory && s.isFrameworkImport(handlerImport) {
+		// Handlers built by the framework itself (adaptor.HTTPHandler(h),
+		// filesystem.New(...)) have no body in the project to analyse.
+		handlerName = ""
+	}
 	if handlerName == "" {
+		s.diagnose(call, "skipped %s %q: cannot resolve handler %s", rc.Method, rc.Path, exprToString(rc.Handler))
 		return RouteInfo{}, false
 	}
 
-	prefix := computePrefix(prefixes, sel.X)
-	fullPath := joinRoutePath(prefix, pathValue)
-
 	return RouteInfo{
-		Method:            method,
+		Method:            rc.Method,
 		Path:              fullPath,
-		Package:           file.Name.Name,
-		File:              filePath,
+		Package:           s.file.Name.Name,
+		File:              s.path,
 		HandlerExpr:       handlerExpr,
 		HandlerName:       handlerName,
 		HandlerImportPath: handlerImport,
-		HandlerID:         buildHandlerID(filePath, handlerImport, handlerName),
+		HandlerID:         buildHandlerID(s.path, handlerImport, handlerName),
+		RouteName:         s.routeNames[call],
+		HandlerFactory:    factory,
+		Middleware:        s.routeMiddleware(rc),
+		pos:               call.Pos(),
 	}, true
 }
 
-func handlerInfoFromCall(call *ast.CallExpr, imports, bindings map[string]string) (string, string, string) {
+// trackRouteName records the name Fiber's app.Get("/users/:id", h).Name("users.get")
+// gives the registration it is chained on. The outer call is visited first, so
+// the name is known when the registration itself is extracted.
+func (s *routeScanner) trackRouteName(call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Name" || len(call.Args) != 1 {
+		return
+	}
+	inner, ok := sel.X.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	if name, ok := s.stringValue(call.Args[0]); ok && strings.TrimSpace(name) != "" {
+		s.routeNames[inner] = strings.TrimSpace(name)
+	}
+}
+
+// fiberRouteCall matches verb helpers such as app.Get("/path", handler).
+func fiberRouteCall(call *ast.CallExpr, str stringResolver) (RouteCall, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return RouteCall{}, false
+	}
+
+	methodName := sel.Sel.Name
+	switch {
+	case hasVerb(methodName):
+	case hasVerb(strings.Title(methodName)):
+		methodName = strings.Title(methodName)
+	default:
+		return RouteCall{}, false
+	}
+
 	if len(call.Args) < 2 {
-		return "", "", ""
+		return RouteCall{}, false
 	}
-	handlerIdx := len(call.Args) - 1
-	switch h := call.Args[handlerIdx].(type) {
+	pathValue, ok := str(call.Args[0])
+	if !ok {
+		return RouteCall{}, false
+	}
+	return RouteCall{
+		Method:     strings.ToUpper(methodName),
+		Path:       pathValue,
+		Receiver:   sel.X,
+		Handler:    call.Args[len(call.Args)-1],
+		Middleware: call.Args[1 : len(call.Args)-1],
+	}, true
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	val, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return val, true
+}
+
+func handlerInfoFromExpr(expr ast.Expr, imports, bindings map[string]string) (string, string, string) {
+	switch h := expr.(type) {
 	case *ast.Ident:
 		return h.Name, h.Name, ""
 	case *ast.SelectorExpr:
@@ -316,6 +598,19 @@ func handlerInfoFromCall(call *ast.CallExpr, imports, bindings map[string]string
 			}
 		}
 		return exprToString(h), h.Sel.Name, ""
+	case *ast.CallExpr:
+		// http.HandlerFunc(fn) and similar adapter conversions wrap the real handler.
+		if inner := unwrapHandlerConversion(h); inner != nil {
+			return handlerInfoFromExpr(inner, imports, bindings)
+		}
+		// users.List(svc) is a handler factory: the handler is the closure the
+		// called function returns.
+		switch h.Fun.(type) {
+		case *ast.Ident, *ast.SelectorExpr:
+			_, name, importPath := handlerInfoFromExpr(h.Fun, imports, bindings)
+			return exprToString(h), name, importPath
+		}
+		return exprToString(h), "", ""
 	default:
 		return exprToString(h), "", ""
 	}
@@ -326,7 +621,7 @@ func hasVerb(name string) bool {
 	return ok
 }
 
-func computePrefix(prefixes map[string]string, expr ast.Expr) string {
+func computePrefix(prefixes map[string]string, expr ast.Expr, str stringResolver) string {
 	switch v := expr.(type) {
 	case *ast.Ident:
 		if prefixes == nil {
@@ -337,21 +632,22 @@ func computePrefix(prefixes map[string]string, expr ast.Expr) string {
 		}
 		return ""
 	case *ast.SelectorExpr:
-		return computePrefix(prefixes, v.X)
+		return computePrefix(prefixes, v.X, str)
 	case *ast.CallExpr:
 		sel, ok := v.Fun.(*ast.SelectorExpr)
 		if !ok || sel.Sel == nil {
 			return ""
 		}
+		if sel.Sel.Name == "With" {
+			return computePrefix(prefixes, sel.X, str)
+		}
 		if sel.Sel.Name != "Group" {
 			return ""
 		}
-		base := computePrefix(prefixes, sel.X)
+		base := computePrefix(prefixes, sel.X, str)
 		if len(v.Args) > 0 {
-			if lit, ok := v.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-				if val, err := strconv.Unquote(lit.Value); err == nil {
-					return joinRoutePath(base, val)
-				}
+			if val, ok := str(v.Args[0]); ok {
+				return joinRoutePath(base, val)
 			}
 		}
 		return base
@@ -360,6 +656,24 @@ func computePrefix(prefixes map[string]string, expr ast.Expr) string {
 	}
 }
 
+// inlineHandlerID identifies a func literal handler by its file and line.
+func inlineHandlerID(filePath string, line int) string {
+	return filepath.ToSlash(filePath) + "::func@" + strconv.Itoa(line)
+}
+
+// inlineHandlerName names a func literal handler after its route, e.g.
+// GET /users/:id becomes getUsersId.
+func inlineHandlerName(method, path string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		sb.WriteString(upperFirst(part))
+	}
+	return sb.String()
+}
+
 func buildHandlerID(filePath, importPath, handlerName string) string {
 	if handlerName == "" {
 		return ""
@@ -371,6 +685,31 @@ func buildHandlerID(filePath, importPath, handlerName string) string {
 	return rel + "::" + handlerName
 }
 
+// defaultImportAlias returns the identifier an import is referenced by when it has
+// no explicit name, skipping major version suffixes such as /v5 or .v3.
+func defaultImportAlias(importPath string) string {
+	base := stdpath.Base(importPath)
+	if isMajorVersionSuffix(base) {
+		base = stdpath.Base(stdpath.Dir(importPath))
+	}
+	if idx := strings.LastIndex(base, ".v"); idx > 0 && isMajorVersionSuffix(base[idx+1:]) {
+		base = base[:idx]
+	}
+	return base
+}
+
+func isMajorVersionSuffix(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // exprToString renders an AST expression back to source (best-effort).
 func exprToString(expr ast.Expr) string {
 	if expr == nil {
@@ -0,0 +1,223 @@
+package core
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// chiRouteCall matches chi's explicit verb helpers: r.Method("GET", "/path", h)
+// and r.MethodFunc(http.MethodGet, "/path", fn).
+func chiRouteCall(call *ast.CallExpr, str stringResolver) (RouteCall, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return RouteCall{}, false
+	}
+	if sel.Sel.Name != "Method" && sel.Sel.Name != "MethodFunc" {
+		return RouteCall{}, false
+	}
+	if len(call.Args) != 3 {
+		return RouteCall{}, false
+	}
+	method, ok := httpMethodFromExpr(call.Args[0], str)
+	if !ok {
+		return RouteCall{}, false
+	}
+	path, ok := str(call.Args[1])
+	if !ok {
+		return RouteCall{}, false
+	}
+	return RouteCall{
+		Method:   method,
+		Path:     path,
+		Receiver: sel.X,
+		Handler:  call.Args[2],
+	}, true
+}
+
+// httpMethodFromExpr resolves "GET" literals and http.MethodGet style constants.
+func httpMethodFromExpr(expr ast.Expr, str stringResolver) (string, bool) {
+	if val, ok := str(expr); ok {
+		method := strings.ToUpper(strings.TrimSpace(val))
+		return method, method != ""
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil || !strings.HasPrefix(sel.Sel.Name, "Method") {
+		return "", false
+	}
+	if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "http" {
+		return "", false
+	}
+	method := strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method"))
+	return method, method != ""
+}
+
+// scopedRouterCall recognises r.Route("/v1", func(r chi.Router) {...}) and
+// r.Group(func(r chi.Router) {...}). It returns the closure body together with a
+// prefix scope in which the closure's router parameter carries the joined prefix.
+// Fiber's app.Route("/v1", func(api fiber.Router) {...}) has the same shape.
+// Literals whose parameter is not a router, like gin's
+// r.Group("/x", func(c *gin.Context) {...}) middleware, are not closures.
+func (s *routeScanner) scopedRouterCall(call *ast.CallExpr, prefixes map[string]string) (*ast.BlockStmt, map[string]string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return nil, nil, false
+	}
+	if sel.Sel.Name != "Route" && sel.Sel.Name != "Group" {
+		return nil, nil, false
+	}
+	var lit *ast.FuncLit
+	for _, arg := range call.Args {
+		if fn, ok := arg.(*ast.FuncLit); ok {
+			lit = fn
+			break
+		}
+	}
+	// Middleware literals return an error; router closures return nothing.
+	if lit == nil || lit.Body == nil || lit.Type.Params == nil || len(lit.Type.Params.List) == 0 {
+		return nil, nil, false
+	}
+	if lit.Type.Results != nil && len(lit.Type.Results.List) > 0 {
+		return nil, nil, false
+	}
+	if !s.isRouterType(lit.Type.Params.List[0].Type) {
+		return nil, nil, false
+	}
+
+	base := computePrefix(prefixes, sel.X, s.stringValue)
+	if len(call.Args) > 0 {
+		if path, ok := s.stringValue(call.Args[0]); ok {
+			base = joinRoutePath(base, path)
+		}
+	}
+
+	scope := make(map[string]string, len(prefixes)+1)
+	for k, v := range prefixes {
+		scope[k] = v
+	}
+	for _, name := range lit.Type.Params.List[0].Names {
+		if name != nil && name.Name != "" && name.Name != "_" {
+			scope[name.Name] = base
+		}
+	}
+	return lit.Body, scope, true
+}
+
+// mountFromCall matches r.Mount("/admin", target) and returns the mounted expression
+// with the prefix it is served under.
+func mountFromCall(call *ast.CallExpr, prefixes map[string]string, str stringResolver) (ast.Expr, string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil || sel.Sel.Name != "Mount" || len(call.Args) != 2 {
+		return nil, "", false
+	}
+	path, ok := str(call.Args[0])
+	if !ok {
+		return nil, "", false
+	}
+	return call.Args[1], joinRoutePath(computePrefix(prefixes, sel.X, str), path), true
+}
+
+// handleMount propagates a Mount prefix to the mounted router. Identifiers receive
+// the prefix directly; calls to local constructor functions such as adminRouter()
+// are walked with the routers they return bound to the prefix. Constructors in
+// other files or packages, called directly or through a variable, receive the
+// prefix once discovery resolves placeholders.
+func (s *routeScanner) handleMount(call *ast.CallExpr, prefixes map[string]string, emit bool) {
+	target, prefix, ok := mountFromCall(call, prefixes, s.stringValue)
+	if !ok {
+		return
+	}
+	switch t := target.(type) {
+	case *ast.Ident:
+		prefixes[t.Name] = prefix
+		if key := s.origins[t.Name]; key != "" && emit {
+			s.addEdge(key, -1, prefix)
+		}
+	case *ast.CallExpr:
+		if !emit {
+			return
+		}
+		var fn *ast.FuncDecl
+		ident, ok := t.Fun.(*ast.Ident)
+		if ok {
+			if _, mounted := s.mounted[ident.Name]; mounted {
+				fn = s.funcs[ident.Name]
+			}
+		}
+		if fn == nil || fn.Body == nil {
+			// Constructors in other files or packages are resolved through
+			// the routers they return.
+			if key := s.calleeKey(t.Fun); key != "" {
+				s.addEdge(key, -1, prefix)
+			}
+			return
+		}
+		// Guard against a constructor that (indirectly) mounts itself.
+		delete(s.mounted, ident.Name)
+		defer func() { s.mounted[ident.Name] = struct{}{} }()
+
+		routers, varTypes, recvTypes, consts, origins := s.routers, s.varTypes, s.recvTypes, s.localConsts, s.origins
+		s.enterFunc(fn)
+		defer func() {
+			s.routers, s.varTypes, s.recvTypes, s.localConsts, s.origins = routers, varTypes, recvTypes, consts, origins
+		}()
+
+		scope := make(map[string]string)
+		for _, name := range returnedIdents(fn.Body) {
+			scope[name] = prefix
+		}
+		s.walkScope(fn.Body, scope)
+	}
+}
+
+// collectMountedFuncs returns local functions used as r.Mount("/x", fn()) targets.
+func collectMountedFuncs(file *ast.File, str stringResolver) map[string]struct{} {
+	result := make(map[string]struct{})
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		target, _, ok := mountFromCall(call, nil, str)
+		if !ok {
+			return true
+		}
+		if inner, ok := target.(*ast.CallExpr); ok {
+			if ident, ok := inner.Fun.(*ast.Ident); ok {
+				result[ident.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// returnedIdents lists identifiers returned directly from body, ignoring nested closures.
+func returnedIdents(body *ast.BlockStmt) []string {
+	var names []string
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, res := range node.Results {
+				if ident, ok := res.(*ast.Ident); ok && ident.Name != "nil" {
+					names = append(names, ident.Name)
+				}
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// chiRouteFinder matches every registration shape a chi router accepts: the
+// explicit Method helpers plus r.Get(...) and r.Handle(...), which chi shares
+// with Fiber and ServeMux.
+func chiRouteFinder(call *ast.CallExpr, str stringResolver) (RouteCall, bool) {
+	for _, match := range []func(*ast.CallExpr, stringResolver) (RouteCall, bool){chiRouteCall, fiberRouteCall, serveMuxRouteCall} {
+		if rc, ok := match(call, str); ok {
+			return rc, true
+		}
+	}
+	return RouteCall{}, false
+}
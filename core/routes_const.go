@@ -0,0 +1,206 @@
+package core
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxConstDepth bounds constant resolution so that cyclic or pathological
+// declarations cannot recurse forever.
+const maxConstDepth = 32
+
+// constDecl is a constant's value expression together with the scope it has to
+// be evaluated in.
+type constDecl struct {
+	value ast.Expr
+	scope constScope
+}
+
+// constScope is what an identifier in a constant expression can refer to.
+type constScope struct {
+	dir     string               // package directory, for package-level constants
+	imports map[string]string    // import aliases of the declaring file
+	locals  map[string]constDecl // function-level constants, if any
+}
+
+// constCache loads the constants of package directories on demand. It is shared
+// by every file scanned in one discovery run.
+type constCache struct {
+	fset     *token.FileSet
+	packages map[string]map[string]constDecl // package dir -> name -> declaration
+	modules  map[string]*moduleSet           // module root -> modules visible from it
+}
+
+func newConstCache() *constCache {
+	return &constCache{
+		fset:     token.NewFileSet(),
+		packages: make(map[string]map[string]constDecl),
+		modules:  make(map[string]*moduleSet),
+	}
+}
+
+// stringValue evaluates expr as a constant string: a literal, a constant
+// declared locally, in the file's package or in a module package it imports,
+// a string(...) conversion or a + concatenation of those.
+func (c *constCache) stringValue(expr ast.Expr, scope constScope, depth int) (string, bool) {
+	if depth > maxConstDepth {
+		return "", false
+	}
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		return stringLiteral(v)
+	case *ast.ParenExpr:
+		return c.stringValue(v.X, scope, depth+1)
+	case *ast.BinaryExpr:
+		if v.Op != token.ADD {
+			return "", false
+		}
+		left, ok := c.stringValue(v.X, scope, depth+1)
+		if !ok {
+			return "", false
+		}
+		right, ok := c.stringValue(v.Y, scope, depth+1)
+		if !ok {
+			return "", false
+		}
+		return left + right, true
+	case *ast.CallExpr:
+		if fn, ok := v.Fun.(*ast.Ident); ok && fn.Name == "string" && len(v.Args) == 1 {
+			return c.stringValue(v.Args[0], scope, depth+1)
+		}
+	case *ast.Ident:
+		if decl, ok := scope.locals[v.Name]; ok {
+			return c.stringValue(decl.value, decl.scope, depth+1)
+		}
+		if decl, ok := c.packageConsts(scope.dir)[v.Name]; ok {
+			return c.stringValue(decl.value, decl.scope, depth+1)
+		}
+	case *ast.SelectorExpr:
+		pkg, ok := v.X.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		dir := c.importDir(scope.dir, scope.imports[pkg.Name])
+		if dir == "" {
+			return "", false
+		}
+		if decl, ok := c.packageConsts(dir)[v.Sel.Name]; ok {
+			return c.stringValue(decl.value, decl.scope, depth+1)
+		}
+	}
+	return "", false
+}
+
+// packageConsts returns the package-level constants declared by the non-test Go
+// files in dir.
+func (c *constCache) packageConsts(dir string) map[string]constDecl {
+	if dir == "" {
+		return nil
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if consts, ok := c.packages[dir]; ok {
+		return consts
+	}
+	consts := make(map[string]constDecl)
+	c.packages[dir] = consts
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return consts
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(c.fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		scope := constScope{dir: dir, imports: fileImportAliases(file)}
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok {
+				addConstDecls(consts, gen, scope)
+			}
+		}
+	}
+	return consts
+}
+
+// importDir maps an import path to its directory when it belongs to the module
+// containing fromDir or to another module of its workspace. Other packages are
+// not resolved.
+func (c *constCache) importDir(fromDir, importPath string) string {
+	if importPath == "" {
+		return ""
+	}
+	root, err := FindModuleRoot(fromDir)
+	if err != nil {
+		return ""
+	}
+	modules, ok := c.modules[root]
+	if !ok {
+		modules = loadModuleSet(root)
+		c.modules[root] = modules
+	}
+	dir, _ := modules.importDir(importPath)
+	return dir
+}
+
+// addConstDecls records the constants of a const block. Specs without values
+// repeat the previous expression, as in Go.
+func addConstDecls(consts map[string]constDecl, gen *ast.GenDecl, scope constScope) {
+	if gen.Tok != token.CONST {
+		return
+	}
+	var values []ast.Expr
+	for _, spec := range gen.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		if len(vs.Values) > 0 {
+			values = vs.Values
+		}
+		if len(values) != len(vs.Names) {
+			continue
+		}
+		for i, name := range vs.Names {
+			if name != nil && name.Name != "_" {
+				consts[name.Name] = constDecl{value: values[i], scope: scope}
+			}
+		}
+	}
+}
+
+// stringValue resolves a path or prefix argument in the scope of the file and
+// function being scanned.
+func (s *routeScanner) stringValue(expr ast.Expr) (string, bool) {
+	if s.consts == nil {
+		return stringLiteral(expr)
+	}
+	return s.consts.stringValue(expr, constScope{
+		dir:     filepath.Dir(s.path),
+		imports: s.imports,
+		locals:  s.localConsts,
+	}, 0)
+}
+
+// trackLocalConsts records const declarations inside the function being walked.
+func (s *routeScanner) trackLocalConsts(stmt *ast.DeclStmt) {
+	gen, ok := stmt.Decl.(*ast.GenDecl)
+	if !ok {
+		return
+	}
+	addConstDecls(s.localConsts, gen, constScope{
+		dir:     filepath.Dir(s.path),
+		imports: s.imports,
+		locals:  s.localConsts,
+	})
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// echoRouteCall matches e.GET("/path", handler, mw...) and
+// g.Add("GET", "/path", handler, mw...). Unlike gin, echo takes the handler
+// before the route-level middleware.
+func echoRouteCall(call *ast.CallExpr, str stringResolver) (RouteCall, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return RouteCall{}, false
+	}
+	name := sel.Sel.Name
+	if _, ok := upperVerbs[name]; ok {
+		if len(call.Args) < 2 {
+			return RouteCall{}, false
+		}
+		path, ok := str(call.Args[0])
+		if !ok {
+			return RouteCall{}, false
+		}
+		return RouteCall{
+			Method:     name,
+			Path:       path,
+			Receiver:   sel.X,
+			Handler:    call.Args[1],
+			Middleware: call.Args[2:],
+		}, true
+	}
+	if name != "Add" || len(call.Args) < 3 {
+		return RouteCall{}, false
+	}
+	method, ok := httpMethodFromExpr(call.Args[0], str)
+	if !ok || strings.HasPrefix(method, "/") {
+		return RouteCall{}, false
+	}
+	path, ok := str(call.Args[1])
+	if !ok {
+		return RouteCall{}, false
+	}
+	return RouteCall{
+		Method:     method,
+		Path:       path,
+		Receiver:   sel.X,
+		Handler:    call.Args[2],
+		Middleware: call.Args[3:],
+	}, true
+}
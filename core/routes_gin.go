@@ -0,0 +1,63 @@
+package core
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// upperVerbs lists the upper-case verb helpers exposed by gin (router.GET) and echo (e.GET).
+var upperVerbs = map[string]struct{}{
+	"CONNECT": {},
+	"DELETE":  {},
+	"GET":     {},
+	"HEAD":    {},
+	"OPTIONS": {},
+	"PATCH":   {},
+	"POST":    {},
+	"PUT":     {},
+	"TRACE":   {},
+}
+
+// ginRouteCall matches router.GET("/path", mw, handler) and
+// router.Handle("GET", "/path", handler).
+func ginRouteCall(call *ast.CallExpr, str stringResolver) (RouteCall, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return RouteCall{}, false
+	}
+	name := sel.Sel.Name
+	if _, ok := upperVerbs[name]; ok {
+		if len(call.Args) < 2 {
+			return RouteCall{}, false
+		}
+		path, ok := str(call.Args[0])
+		if !ok {
+			return RouteCall{}, false
+		}
+		return RouteCall{
+			Method:     name,
+			Path:       path,
+			Receiver:   sel.X,
+			Handler:    call.Args[len(call.Args)-1],
+			Middleware: call.Args[1 : len(call.Args)-1],
+		}, true
+	}
+	if name != "Handle" || len(call.Args) < 3 {
+		return RouteCall{}, false
+	}
+	method, ok := httpMethodFromExpr(call.Args[0], str)
+	if !ok || strings.HasPrefix(method, "/") {
+		return RouteCall{}, false
+	}
+	path, ok := str(call.Args[1])
+	if !ok {
+		return RouteCall{}, false
+	}
+	return RouteCall{
+		Method:     method,
+		Path:       path,
+		Receiver:   sel.X,
+		Handler:    call.Args[len(call.Args)-1],
+		Middleware: call.Args[2 : len(call.Args)-1],
+	}, true
+}
@@ -0,0 +1,213 @@
+package core
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// middlewareGuard is middleware attached with Use or Group to the routes under
+// prefix, the way Fiber matches it.
+type middlewareGuard struct {
+	prefix string
+	ids    []string
+	file   string
+	scope  ast.Node  // function body or router closure the middleware is attached in
+	pos    token.Pos // position of the Use/Group call
+	local  bool      // attached inside a router closure, which keeps it to itself
+}
+
+// covers reports whether the guard applies to route. Within the guard's scope
+// only routes registered after it are covered; other functions cannot be
+// ordered against it and are covered by prefix alone.
+func (g middlewareGuard) covers(route RouteInfo, prefixes []string) bool {
+	if route.File == g.file && g.scope != nil {
+		inside := g.scope.Pos() <= route.pos && route.pos < g.scope.End()
+		if inside && route.pos < g.pos {
+			return false
+		}
+		if !inside && g.local {
+			return false
+		}
+	}
+	for _, prefix := range prefixes {
+		if hasPathPrefix(route.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPathPrefix reports whether path lies under prefix, matching whole segments.
+func hasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// trackMiddleware records the middleware attached by router.Use(mw...),
+// router.Use("/prefix", mw...) and router.Group("/prefix", mw...). For
+// frameworks with GroupMiddleware it is bound to the router value instead.
+func (s *routeScanner) trackMiddleware(call *ast.CallExpr, prefixes map[string]string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "Use" && sel.Sel.Name != "Group") || !s.isRouterExpr(sel.X) {
+		return
+	}
+	if s.groupMiddleware {
+		if router, ok := sel.X.(*ast.Ident); ok && sel.Sel.Name == "Use" {
+			s.bindGroupMiddleware(router.Name, call, middlewareIDs(call.Args), prefixes)
+		}
+		return
+	}
+	prefix := computePrefix(prefixes, sel.X, s.stringValue)
+	args := call.Args
+	if len(args) > 0 {
+		if path, ok := s.stringValue(args[0]); ok {
+			prefix = joinRoutePath(prefix, path)
+			args = args[1:]
+		}
+	}
+	ids := middlewareIDs(args)
+	if len(ids) == 0 || s.discovery == nil {
+		return
+	}
+	s.discovery.guards = append(s.discovery.guards, middlewareGuard{
+		prefix: prefix,
+		ids:    ids,
+		file:   s.path,
+		scope:  s.scope,
+		pos:    call.Pos(),
+		local:  s.inClosure,
+	})
+}
+
+// bindGroupMiddlewareAssign binds the middleware of api := r.Group("/api", mw...)
+// to api, for frameworks with GroupMiddleware.
+func (s *routeScanner) bindGroupMiddlewareAssign(stmt *ast.AssignStmt, prefixes map[string]string) {
+	if !s.groupMiddleware || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+		return
+	}
+	ident, ok := stmt.Lhs[0].(*ast.Ident)
+	call, isCall := stmt.Rhs[0].(*ast.CallExpr)
+	if !ok || !isCall || len(call.Args) < 2 {
+		return
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Group" || !s.isRouterExpr(sel.X) {
+		return
+	}
+	s.bindGroupMiddleware(ident.Name, call, middlewareIDs(call.Args[1:]), prefixes)
+}
+
+// bindGroupMiddleware binds middleware to the routes later registered on the
+// router variable and on the groups later created from it, which is how gin
+// and echo apply Use and Group middleware. The variable's prefix becomes a
+// placeholder resolving to its current prefix, so the routes registered
+// through it, here or in the functions it is passed to, resolve through the
+// placeholder and are guarded by ids; sibling groups sharing its path are not.
+func (s *routeScanner) bindGroupMiddleware(router string, call *ast.CallExpr, ids []string, prefixes map[string]string) {
+	if len(ids) == 0 || s.discovery == nil {
+		return
+	}
+	key := fmt.Sprintf("%s#%d", s.path, s.fset.Position(call.Pos()).Offset)
+	if _, bound := s.discovery.groupGuards[key]; !bound {
+		s.discovery.groupGuards[key] = ids
+		s.addEdge(key, 0, prefixes[router])
+	}
+	prefixes[router] = paramPrefix(key, 0)
+}
+
+// routeMiddleware returns the middleware passed with the registration itself,
+// including chi's r.With(mw).Get(...) chains on its receiver and, for
+// frameworks with GroupMiddleware, r.Group("/x", mw).GET(...) chains.
+func (s *routeScanner) routeMiddleware(rc RouteCall) []string {
+	var ids []string
+	var with func(expr ast.Expr)
+	with = func(expr ast.Expr) {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		with(sel.X)
+		switch {
+		case sel.Sel.Name == "With":
+			ids = append(ids, middlewareIDs(call.Args)...)
+		case sel.Sel.Name == "Group" && s.groupMiddleware && len(call.Args) > 1:
+			ids = append(ids, middlewareIDs(call.Args[1:])...)
+		}
+	}
+	with(rc.Receiver)
+	return append(ids, middlewareIDs(rc.Middleware)...)
+}
+
+func middlewareIDs(exprs []ast.Expr) []string {
+	var ids []string
+	for _, expr := range exprs {
+		if id := middlewareID(expr); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// middlewareID names middleware as written where it is attached: the called
+// function for jwtware.New(cfg) or requireAuth("admin"), else the expression
+// itself (requireAuth, mw.Auth). Func literals have no name.
+func middlewareID(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.ParenExpr:
+		return middlewareID(v.X)
+	case *ast.CallExpr:
+		return middlewareID(v.Fun)
+	case *ast.Ident, *ast.SelectorExpr:
+		return exprToString(v)
+	}
+	return ""
+}
+
+// applyMiddlewareGuards prepends the guarding middleware to the middleware of
+// every route. It runs once all files are scanned and resolve expands
+// placeholder prefixes like route paths, so a group created in main.go with
+// jwtware.New() covers the routes users.Register adds to it.
+func applyMiddlewareGuards(routes []RouteInfo, guards []middlewareGuard, resolve func(string) []resolvedPath) {
+	if len(guards) == 0 {
+		return
+	}
+	prefixes := make([][]string, len(guards))
+	for i, g := range guards {
+		for _, rp := range resolve(g.prefix) {
+			prefixes[i] = append(prefixes[i], rp.path)
+		}
+	}
+	for i := range routes {
+		var ids []string
+		for j, g := range guards {
+			if g.covers(routes[i], prefixes[j]) {
+				ids = append(ids, g.ids...)
+			}
+		}
+		if len(ids) > 0 {
+			routes[i].Middleware = uniqueStrings(append(ids, routes[i].Middleware...))
+		}
+	}
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := values[:0]
+	for _, v := range values {
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,307 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// routeParam is a path parameter parsed from a single route segment: Fiber's
+// :id, :id<int;min(1)>, :name?, * and +, and the braced {id} form.
+type routeParam struct {
+	name        string
+	optional    bool
+	constraints []string
+}
+
+// parseRouteParam parses segment as a path parameter. Unnamed wildcards are
+// named "wildcard"; a bare ":" yields an empty name.
+func parseRouteParam(segment string) (routeParam, bool) {
+	segment = strings.TrimSpace(segment)
+	switch {
+	case strings.HasPrefix(segment, ":"):
+		return parseColonParam(segment[1:]), true
+	case strings.HasPrefix(segment, "*"), strings.HasPrefix(segment, "+"):
+		name := strings.TrimSpace(segment[1:])
+		if name == "" {
+			name = "wildcard"
+		}
+		return routeParam{name: name}, true
+	case strings.HasPrefix(segment, "{"):
+		if name, ok := bracedParamName(segment); ok {
+			return routeParam{name: name}, true
+		}
+	}
+	return routeParam{}, false
+}
+
+// parseColonParam parses the part of a Fiber segment after the colon:
+// name, name?, name<constraints> or name<constraints>?.
+func parseColonParam(rest string) routeParam {
+	var p routeParam
+	end := strings.IndexAny(rest, "<?")
+	if end < 0 {
+		p.name = strings.TrimSpace(rest)
+		return p
+	}
+	p.name = strings.TrimSpace(rest[:end])
+	rest = rest[end:]
+	if strings.HasPrefix(rest, "<") {
+		body, tail := splitConstraintBody(rest[1:])
+		p.constraints = splitTopLevel(body, ';')
+		rest = tail
+	}
+	p.optional = strings.HasPrefix(rest, "?")
+	return p
+}
+
+// splitConstraintBody splits s at the '>' closing a constraint list, skipping
+// parentheses so that regex(a>b) stays intact.
+func splitConstraintBody(s string) (string, string) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case '>':
+			if depth == 0 {
+				return s[:i], s[i+1:]
+			}
+		}
+	}
+	return s, ""
+}
+
+// splitTopLevel splits s on sep outside of parentheses.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case sep:
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if rest := strings.TrimSpace(s[start:]); rest != "" || len(parts) > 0 {
+		parts = append(parts, rest)
+	}
+	return parts
+}
+
+// routePathParams returns the path parameters of routePath in order, typed by
+// their Fiber constraints.
+func routePathParams(routePath string) []Parameter {
+	var params []Parameter
+	seen := make(map[string]struct{})
+	for _, segment := range strings.Split(strings.TrimSpace(routePath), "/") {
+		rp, ok := parseRouteParam(segment)
+		if !ok || rp.name == "" {
+			continue
+		}
+		key := strings.ToLower(rp.name)
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		param := Parameter{Name: rp.name, In: "path", Type: "string", Required: true}
+		for _, c := range rp.constraints {
+			applyRouteConstraint(&param, c)
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// applyRouteConstraint maps a Fiber route constraint onto the parameter schema.
+// Unknown constraints are ignored.
+func applyRouteConstraint(p *Parameter, constraint string) {
+	name, args := constraint, []string(nil)
+	if open := strings.Index(constraint, "("); open >= 0 && strings.HasSuffix(constraint, ")") {
+		name = constraint[:open]
+		inner := constraint[open+1 : len(constraint)-1]
+		if name == "regex" || name == "datetime" {
+			args = []string{inner}
+		} else {
+			args = splitTopLevel(inner, ',')
+		}
+	}
+	switch strings.TrimSpace(name) {
+	case "int":
+		p.Type = "int"
+	case "bool":
+		p.Type = "bool"
+	case "float":
+		p.Type = "float64"
+	case "alpha":
+		p.Pattern = "^[a-zA-Z]+$"
+	case "guid":
+		p.Format = "uuid"
+	case "datetime":
+		p.Format = "date-time"
+		if len(args) == 1 && strings.ReplaceAll(args[0], `\`, "") == "2006-01-02" {
+			p.Format = "date"
+		}
+	case "regex":
+		if len(args) == 1 {
+			p.Pattern = args[0]
+		}
+	case "minLen":
+		p.MinLength = intArg(args, 0)
+	case "maxLen":
+		p.MaxLength = intArg(args, 0)
+	case "len":
+		p.MinLength, p.MaxLength = intArg(args, 0), intArg(args, 0)
+	case "betweenLen":
+		p.MinLength, p.MaxLength = intArg(args, 0), intArg(args, 1)
+	case "min":
+		p.Type, p.Minimum = "int", floatArg(args, 0)
+	case "max":
+		p.Type, p.Maximum = "int", floatArg(args, 0)
+	case "range":
+		p.Type, p.Minimum, p.Maximum = "int", floatArg(args, 0), floatArg(args, 1)
+	}
+}
+
+func intArg(args []string, idx int) *int {
+	if idx >= len(args) {
+		return nil
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(args[idx]))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func floatArg(args []string, idx int) *float64 {
+	if idx >= len(args) {
+		return nil
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(args[idx]), 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// expandOptionalSegments returns the paths routePath matches when its optional
+// parameters (:name?) are omitted or given. An omitted optional parameter also
+// omits the optional ones after it, as Fiber fills them left to right, so
+// /:a?/:b? yields "/", "/:a" and "/:a/:b".
+func expandOptionalSegments(routePath string) []string {
+	segments := strings.Split(routePath, "/")
+	var optional []int
+	for i, segment := range segments {
+		if rp, ok := parseRouteParam(segment); ok && rp.optional {
+			optional = append(optional, i)
+		}
+	}
+	if len(optional) == 0 {
+		return []string{routePath}
+	}
+
+	variants := make([]string, 0, len(optional)+1)
+	for keep := 0; keep <= len(optional); keep++ {
+		parts := make([]string, 0, len(segments))
+		for i, segment := range segments {
+			if n := indexOf(optional, i); n >= 0 {
+				if n >= keep {
+					continue
+				}
+				segment = strings.TrimSuffix(strings.TrimSpace(segment), "?")
+			}
+			parts = append(parts, segment)
+		}
+		path := strings.Join(parts, "/")
+		if path == "" {
+			path = "/"
+		}
+		variants = append(variants, path)
+	}
+	return variants
+}
+
+func indexOf(values []int, v int) int {
+	for i, value := range values {
+		if value == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// withRoutePathParams fits the path parameters of handler to routePath: those
+// not in the path are dropped and missing ones are added from the route's
+// constraints. Parameters of the handler keep what they set and take the
+// route's constraints for the rest.
+func withRoutePathParams(handler HandlerInfo, routePath string) HandlerInfo {
+	routeParams := routePathParams(routePath)
+	inPath := make(map[string]bool, len(routeParams))
+	byName := make(map[string]Parameter, len(routeParams))
+	for _, p := range routeParams {
+		inPath[strings.ToLower(p.Name)] = false
+		byName[strings.ToLower(p.Name)] = p
+	}
+	params := make([]Parameter, 0, len(handler.Params)+len(routeParams))
+	for _, p := range handler.Params {
+		if strings.EqualFold(p.In, "path") {
+			key := strings.ToLower(p.Name)
+			if _, ok := inPath[key]; !ok {
+				continue
+			}
+			inPath[key] = true
+			p = withRouteConstraints(p, byName[key])
+		}
+		params = append(params, p)
+	}
+	for _, p := range routeParams {
+		if !inPath[strings.ToLower(p.Name)] {
+			params = append(params, p)
+		}
+	}
+	handler.Params = params
+	return handler
+}
+
+// withRouteConstraints completes a handler's path parameter with the route's
+// constraints it leaves unset; a route type wins over an inferred string.
+func withRouteConstraints(p, route Parameter) Parameter {
+	if (p.Type == "" || p.Type == "string") && route.Type != "" {
+		p.Type = route.Type
+	}
+	if p.Format == "" {
+		p.Format = route.Format
+	}
+	if p.Pattern == "" {
+		p.Pattern = route.Pattern
+	}
+	if p.Minimum == nil {
+		p.Minimum = route.Minimum
+	}
+	if p.Maximum == nil {
+		p.Maximum = route.Maximum
+	}
+	if p.MinLength == nil {
+		p.MinLength = route.MinLength
+	}
+	if p.MaxLength == nil {
+		p.MaxLength = route.MaxLength
+	}
+	return p
+}
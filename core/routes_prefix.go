@@ -0,0 +1,351 @@
+package core
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// discovery holds the state shared by every file scanned in one FindRoutes run.
+type discovery struct {
+	frameworks []Framework
+	consts     *constCache
+	edges      []prefixEdge
+	guards     []middlewareGuard
+	packages   map[string]*packageRouters // funcKey(dir, package name) -> top-level routers
+	dirs       map[string]*packageRouters // imported package directory -> top-level routers, see packageAt
+	// groupGuards maps the placeholder of a gin or echo router value to the
+	// middleware bound to it, see bindGroupMiddleware.
+	groupGuards map[string][]string
+}
+
+func newDiscovery(frameworks []Framework) *discovery {
+	return &discovery{
+		frameworks:  frameworks,
+		consts:      newConstCache(),
+		packages:    make(map[string]*packageRouters),
+		dirs:        make(map[string]*packageRouters),
+		groupGuards: make(map[string][]string),
+	}
+}
+
+// prefixEdge records that the function callee receives, as parameter param,
+// a router served under prefix. param is -1 for a router the callee returns
+// that is then mounted, as in app.Mount("/users", users.New()).
+type prefixEdge struct {
+	callee string
+	param  int
+	prefix string
+}
+
+const placeholderMark = "\x00"
+
+// paramPrefix is the placeholder prefix of the param-th parameter of callee.
+// Routes registered on a router a function receives, or returns to be mounted,
+// do not know their prefix while the file is scanned, so their paths start
+// with the placeholder until resolveRoutePrefixes substitutes it.
+func paramPrefix(callee string, param int) string {
+	return placeholderMark + callee + placeholderMark + strconv.Itoa(param) + placeholderMark
+}
+
+// splitParamPrefix splits a path produced under a paramPrefix into the
+// placeholder's function, parameter and the remaining path.
+func splitParamPrefix(path string) (string, int, string, bool) {
+	if !strings.HasPrefix(path, placeholderMark) {
+		return "", 0, "", false
+	}
+	parts := strings.SplitN(path[len(placeholderMark):], placeholderMark, 3)
+	if len(parts) != 3 {
+		return "", 0, "", false
+	}
+	param, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, "", false
+	}
+	return parts[0], param, parts[2], true
+}
+
+// withoutParamPrefix drops a leading placeholder, leaving the path relative to
+// the router parameter.
+func withoutParamPrefix(path string) string {
+	if _, _, rest, ok := splitParamPrefix(path); ok {
+		return rest
+	}
+	return path
+}
+
+// funcKey identifies a function or type by package directory and name. A
+// method is named by its receiver type, as in funcKey(dir, "Users.Register"),
+// so that methods sharing a name on different types are kept apart.
+func funcKey(dir, name string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return filepath.ToSlash(filepath.Clean(dir)) + "." + name
+}
+
+// funcPrefixes returns the prefix scope for the body of fn: routers it returns
+// and router parameters start at placeholders resolved from fn's callers.
+func (s *routeScanner) funcPrefixes(fn *ast.FuncDecl, prefixes map[string]string) map[string]string {
+	scope := make(map[string]string, len(prefixes))
+	for k, v := range prefixes {
+		scope[k] = v
+	}
+	key := funcKey(filepath.Dir(s.path), fn.Name.Name)
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		key = s.typeKey(fn.Recv.List[0].Type) + "." + fn.Name.Name
+	}
+	if fn.Body != nil && fn.Type.Results != nil {
+		for _, name := range returnedIdents(fn.Body) {
+			scope[name] = paramPrefix(key, -1)
+		}
+	}
+	idx := 0
+	for _, field := range fn.Type.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			idx++
+			continue
+		}
+		for _, name := range names {
+			if _, ok := s.routers[name.Name]; ok && name.Name != "_" {
+				scope[name.Name] = paramPrefix(key, idx)
+			}
+			idx++
+		}
+	}
+	return scope
+}
+
+// calleeKey resolves the function called through fun: a function of the same
+// package, a function of an imported module package, or a method on a value
+// whose type valueTypeKey tells (users.NewHandler(svc).Register). It returns
+// "" for calls that cannot be followed.
+func (s *routeScanner) calleeKey(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return funcKey(filepath.Dir(s.path), f.Name)
+	case *ast.SelectorExpr:
+		if pkg, ok := f.X.(*ast.Ident); ok && s.imports[pkg.Name] != "" {
+			if dir := s.importedDir(pkg); dir != "" {
+				return funcKey(dir, f.Sel.Name)
+			}
+			return ""
+		}
+		if recv := s.valueTypeKey(f.X); recv != "" {
+			return recv + "." + f.Sel.Name
+		}
+	}
+	return ""
+}
+
+// typeKey returns the funcKey of the named type typ refers to: (a pointer to)
+// a type declared in the scanned package or in an imported module package. It
+// returns "" for other types.
+func (s *routeScanner) typeKey(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return s.typeKey(t.X)
+	case *ast.ParenExpr:
+		return s.typeKey(t.X)
+	case *ast.IndexExpr:
+		return s.typeKey(t.X)
+	case *ast.IndexListExpr:
+		return s.typeKey(t.X)
+	case *ast.Ident:
+		if _, ok := s.pkg.types[t.Name]; ok {
+			return funcKey(filepath.Dir(s.path), t.Name)
+		}
+	case *ast.SelectorExpr:
+		if dir := s.importedDir(t.X); dir != "" {
+			return funcKey(dir, t.Sel.Name)
+		}
+	}
+	return ""
+}
+
+// valueTypeKey returns the typeKey of the value expr holds or builds: a
+// variable of a known type, &T{}, T{}, new(T), or a call to a function of the
+// package or of an imported module package returning a T.
+func (s *routeScanner) valueTypeKey(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.Ident:
+		return s.recvTypes[v.Name]
+	case *ast.ParenExpr:
+		return s.valueTypeKey(v.X)
+	case *ast.UnaryExpr:
+		if v.Op == token.AND {
+			return s.valueTypeKey(v.X)
+		}
+	case *ast.CompositeLit:
+		if v.Type != nil {
+			return s.typeKey(v.Type)
+		}
+	case *ast.CallExpr:
+		switch fn := v.Fun.(type) {
+		case *ast.Ident:
+			if fn.Name == "new" && len(v.Args) == 1 {
+				return s.typeKey(v.Args[0])
+			}
+			return s.pkg.results[fn.Name]
+		case *ast.SelectorExpr:
+			if dir := s.importedDir(fn.X); dir != "" && s.discovery != nil {
+				if pkg := s.discovery.packageAt(dir); pkg != nil {
+					return pkg.results[fn.Sel.Name]
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// importedDir returns the directory of the module package x names, or "" when
+// x is not an import of one.
+func (s *routeScanner) importedDir(x ast.Expr) string {
+	pkg, ok := x.(*ast.Ident)
+	if !ok || s.imports[pkg.Name] == "" || s.consts == nil {
+		return ""
+	}
+	return s.consts.importDir(filepath.Dir(s.path), s.imports[pkg.Name])
+}
+
+// recordRouterArgs records the routers passed to call, e.g. users.Register(api).
+// Calls on routers themselves (Group, Mount, Use, ...) are not followed. A
+// method whose receiver type is unknown is reported instead, since following
+// every method of that name would register the routes under each caller.
+func (s *routeScanner) recordRouterArgs(call *ast.CallExpr, prefixes map[string]string) {
+	sel, method := call.Fun.(*ast.SelectorExpr)
+	if method && s.isRouterExpr(sel.X) {
+		return
+	}
+	var key string
+	for i, arg := range call.Args {
+		if !s.isRouterExpr(arg) {
+			continue
+		}
+		if key == "" {
+			if key = s.calleeKey(call.Fun); key == "" {
+				if pkg, ok := sel.X.(*ast.Ident); method && (!ok || s.imports[pkg.Name] == "") {
+					s.diagnose(call, "router %s passed to %s is not followed: the receiver type is unknown", exprToString(arg), exprToString(call.Fun))
+				}
+				return
+			}
+		}
+		s.addEdge(key, i, computePrefix(prefixes, arg, s.stringValue))
+	}
+}
+
+// trackRouterOrigin remembers which function produced a variable, so that
+// sub := users.New(); app.Mount("/users", sub) can be followed.
+func (s *routeScanner) trackRouterOrigin(stmt *ast.AssignStmt) {
+	if len(stmt.Lhs) != len(stmt.Rhs) {
+		return
+	}
+	for i, rhs := range stmt.Rhs {
+		ident, ok := stmt.Lhs[i].(*ast.Ident)
+		call, isCall := rhs.(*ast.CallExpr)
+		if !ok || !isCall {
+			continue
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && s.isRouterExpr(sel.X) {
+			continue
+		}
+		if key := s.calleeKey(call.Fun); key != "" {
+			s.origins[ident.Name] = key
+		}
+	}
+}
+
+func (s *routeScanner) addEdge(callee string, param int, prefix string) {
+	if s.discovery != nil {
+		s.discovery.edges = append(s.discovery.edges, prefixEdge{callee: callee, param: param, prefix: prefix})
+	}
+}
+
+// resolveRoutePrefixes substitutes the placeholders in route paths with the
+// prefixes of the routers passed in by callers, once every file has been
+// scanned. A function called with several routers yields one route per
+// distinct prefix; one without callers keeps the path relative to the root.
+// The middleware bound to the group placeholders a
+// path resolves through (see bindGroupMiddleware) guards that route.
+func resolveRoutePrefixes(routes []RouteInfo, resolve func(string) []resolvedPath, groupGuards map[string][]string) []RouteInfo {
+	result := make([]RouteInfo, 0, len(routes))
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, placeholderMark) {
+			result = append(result, route)
+			continue
+		}
+		for _, rp := range resolve(route.Path) {
+			resolved := route
+			resolved.Path = rp.path
+			var ids []string
+			for i := len(rp.via) - 1; i >= 0; i-- {
+				ids = append(ids, groupGuards[rp.via[i]]...)
+			}
+			if len(ids) > 0 {
+				resolved.Middleware = uniqueStrings(append(ids, route.Middleware...))
+			}
+			result = append(result, resolved)
+		}
+	}
+	return result
+}
+
+// resolvedPath is a path expanded from its placeholders, with the callees of
+// the placeholders it went through, innermost first.
+type resolvedPath struct {
+	path string
+	via  []string
+}
+
+// prefixResolver returns the function expanding a path that starts with a
+// placeholder into the paths it takes under each caller's prefix.
+func prefixResolver(edges []prefixEdge) func(string) []resolvedPath {
+	type param struct {
+		callee string
+		idx    int
+	}
+	incoming := make(map[param][]string)
+	for _, e := range edges {
+		p := param{e.callee, e.param}
+		incoming[p] = append(incoming[p], e.prefix)
+	}
+
+	var resolve func(path string, visiting map[param]bool) []resolvedPath
+	resolve = func(path string, visiting map[param]bool) []resolvedPath {
+		callee, idx, rest, ok := splitParamPrefix(path)
+		if !ok {
+			return []resolvedPath{{path: path}}
+		}
+		p := param{callee, idx}
+		prefixes := incoming[p]
+		if len(prefixes) == 0 || visiting[p] {
+			return []resolvedPath{{path: joinRoutePath("", rest), via: []string{callee}}}
+		}
+		visiting[p] = true
+		defer delete(visiting, p)
+
+		var out []resolvedPath
+		seen := make(map[string]struct{})
+		for _, prefix := range prefixes {
+			for _, base := range resolve(prefix, visiting) {
+				full := resolvedPath{
+					path: joinRoutePath(base.path, rest),
+					via:  append([]string{callee}, base.via...),
+				}
+				key := full.path + placeholderMark + strings.Join(full.via, placeholderMark)
+				if _, dup := seen[key]; dup {
+					continue
+				}
+				seen[key] = struct{}{}
+				out = append(out, full)
+			}
+		}
+		return out
+	}
+	return func(path string) []resolvedPath {
+		return resolve(path, make(map[param]bool))
+	}
+}
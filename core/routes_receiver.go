@@ -0,0 +1,633 @@
+package core
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// routerDerivingMethods return a router or group that shares the receiver's routes.
+var routerDerivingMethods = map[string]struct{}{
+	"Group": {},
+	"Route": {},
+	"With":  {},
+}
+
+// isRouterExpr reports whether expr is known to evaluate to a router: a tracked
+// variable or struct field, a framework package (http.HandleFunc), a router
+// constructor, or a Group/Route/With call on another router.
+func (s *routeScanner) isRouterExpr(expr ast.Expr) bool {
+	switch v := expr.(type) {
+	case *ast.ParenExpr:
+		return s.isRouterExpr(v.X)
+	case *ast.StarExpr:
+		return s.isRouterExpr(v.X)
+	case *ast.UnaryExpr:
+		return v.Op == token.AND && s.isRouterExpr(v.X)
+	case *ast.Ident:
+		if _, ok := s.routers[v.Name]; ok {
+			return true
+		}
+		return s.isFrameworkPackage(v.Name)
+	case *ast.SelectorExpr:
+		return s.isRouterField(v)
+	case *ast.TypeAssertExpr:
+		return v.Type != nil && s.isRouterType(v.Type)
+	case *ast.CompositeLit:
+		return s.isRouterType(v.Type)
+	case *ast.CallExpr:
+		switch fn := v.Fun.(type) {
+		case *ast.SelectorExpr:
+			if _, ok := routerDerivingMethods[fn.Sel.Name]; ok && s.isRouterExpr(fn.X) {
+				return true
+			}
+			return s.frameworkMember(fn, func(fw Framework) []string { return fw.RouterConstructors })
+		case *ast.Ident:
+			decl := s.funcs[fn.Name]
+			return decl != nil && s.returnsRouter(decl.Type, 0)
+		}
+	}
+	return false
+}
+
+// isRouterType reports whether typ, as written in source, names a router type
+// of one of the scanned frameworks or a package type embedding one.
+func (s *routeScanner) isRouterType(typ ast.Expr) bool {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return s.isRouterType(t.X)
+	case *ast.ParenExpr:
+		return s.isRouterType(t.X)
+	case *ast.SelectorExpr:
+		return s.frameworkMember(t, func(fw Framework) []string { return fw.RouterTypes })
+	case *ast.Ident:
+		if s.pkg != nil {
+			_, ok := s.pkg.routerTypes[t.Name]
+			return ok
+		}
+	}
+	return false
+}
+
+// isRouteMethod reports whether the method name of type fn registers routes
+// the way the frameworks' verb helpers do, Get(path string, handler ...): a
+// local type declaring one wraps a router behind its own API.
+func isRouteMethod(name string, fn *ast.FuncType) bool {
+	if fn.Params == nil || len(fn.Params.List) < 2 {
+		return false
+	}
+	if _, ok := httpVerbs[name]; !ok {
+		if _, ok := upperVerbs[name]; !ok {
+			return false
+		}
+	}
+	params := fn.Params.List
+	if first, ok := params[0].Type.(*ast.Ident); !ok || first.Name != "string" {
+		return false
+	}
+	for _, field := range params[1:] {
+		if isHandlerParam(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHandlerParam reports whether field takes handlers: a func, a Handler or
+// HandlerFunc type, or a parameter named handler.
+func isHandlerParam(field *ast.Field) bool {
+	typ := field.Type
+	if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+		typ = ellipsis.Elt
+	}
+	if _, ok := typ.(*ast.FuncType); ok {
+		return true
+	}
+	if name := exprToString(typ); strings.HasSuffix(name, "Handler") || strings.HasSuffix(name, "HandlerFunc") {
+		return true
+	}
+	for _, name := range field.Names {
+		if strings.Contains(strings.ToLower(name.Name), "handler") {
+			return true
+		}
+	}
+	return false
+}
+
+// embedsRouter reports whether the type declared as typ is a router: a router
+// type itself, an interface or struct embedding one, or an interface with
+// route methods.
+func (s *routeScanner) embedsRouter(typ ast.Expr) bool {
+	var fields *ast.FieldList
+	switch t := typ.(type) {
+	case *ast.InterfaceType:
+		fields = t.Methods
+	case *ast.StructType:
+		fields = t.Fields
+	default:
+		return s.isRouterType(typ)
+	}
+	if fields == nil {
+		return false
+	}
+	for _, field := range fields.List {
+		if len(field.Names) == 0 && s.isRouterType(field.Type) {
+			return true
+		}
+		if fn, ok := field.Type.(*ast.FuncType); ok && len(field.Names) == 1 && isRouteMethod(field.Names[0].Name, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// frameworkMember reports whether sel is pkg.Name where pkg imports one of the
+// frameworks and Name is listed by names for that framework.
+func (s *routeScanner) frameworkMember(sel *ast.SelectorExpr, names func(Framework) []string) bool {
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || sel.Sel == nil {
+		return false
+	}
+	importPath := s.imports[pkg.Name]
+	if importPath == "" {
+		return false
+	}
+	for _, fw := range s.frameworks {
+		if !matchesImportPrefix(importPath, fw.ImportPaths) {
+			continue
+		}
+		for _, name := range names(fw) {
+			if name == sel.Sel.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *routeScanner) isFrameworkPackage(alias string) bool {
+	return s.isFrameworkImport(s.imports[alias])
+}
+
+// isFrameworkImport reports whether importPath is a package of one of the
+// frameworks, including its sub-packages.
+func (s *routeScanner) isFrameworkImport(importPath string) bool {
+	if importPath == "" {
+		return false
+	}
+	for _, fw := range s.frameworks {
+		if matchesImportPrefix(importPath, fw.ImportPaths) {
+			return true
+		}
+	}
+	return false
+}
+
+// returnsRouter reports whether the idx-th result of fn is a router type.
+func (s *routeScanner) returnsRouter(fn *ast.FuncType, idx int) bool {
+	if fn == nil || fn.Results == nil {
+		return false
+	}
+	pos := 0
+	for _, field := range fn.Results.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		if idx < pos+n {
+			return s.isRouterType(field.Type)
+		}
+		pos += n
+	}
+	return false
+}
+
+// enterFunc resets the per-function state: local constants are dropped and the
+// tracked routers become the package-level ones plus the router parameters of
+// fn. A parameter of a Register function also counts as a router when its type
+// is declared in a framework package, or is a package struct wrapping a router
+// in a field. Calls on other parameters are reported as skipped routes.
+func (s *routeScanner) enterFunc(fn *ast.FuncDecl) {
+	s.localConsts = make(map[string]constDecl)
+	s.origins = make(map[string]string)
+	s.routers = make(map[string]struct{}, len(s.pkg.vars))
+	for name := range s.pkg.vars {
+		s.routers[name] = struct{}{}
+	}
+	s.varTypes = make(map[string]string, len(s.pkg.varTypes))
+	for name, typ := range s.pkg.varTypes {
+		s.varTypes[name] = typ
+	}
+	s.recvTypes = make(map[string]string, len(s.pkg.recvTypes))
+	for name, key := range s.pkg.recvTypes {
+		s.recvTypes[name] = key
+	}
+	s.bindRouterParams(fn.Recv, false)
+	s.bindRouterParams(fn.Type.Params, isRegisterFunc(fn.Name.Name))
+}
+
+func isRegisterFunc(name string) bool {
+	return strings.HasPrefix(strings.ToLower(name), "register")
+}
+
+// bindRouterParams tracks the router parameters of a function and the
+// parameters of package struct types. With register set, parameters of a type
+// declared in a framework package or wrapping a router are routers too.
+func (s *routeScanner) bindRouterParams(params *ast.FieldList, register bool) {
+	if params == nil {
+		return
+	}
+	for _, field := range params.List {
+		localType := s.localTypeName(field.Type)
+		typeKey := s.typeKey(field.Type)
+		router := s.isRouterType(field.Type) || (register && (len(s.pkg.fields[localType]) > 0 || s.isFrameworkTypeExpr(field.Type)))
+		for _, name := range field.Names {
+			if name == nil || name.Name == "_" {
+				continue
+			}
+			if localType != "" {
+				s.varTypes[name.Name] = localType
+			}
+			if typeKey != "" {
+				s.recvTypes[name.Name] = typeKey
+			}
+			if router {
+				s.routers[name.Name] = struct{}{}
+			}
+		}
+	}
+}
+
+// localTypeName returns the name of the package type typ refers to, or "" when
+// typ is not a (pointer to a) type declared in the scanned package.
+func (s *routeScanner) localTypeName(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return s.localTypeName(t.X)
+	case *ast.ParenExpr:
+		return s.localTypeName(t.X)
+	case *ast.Ident:
+		if _, ok := s.pkg.types[t.Name]; ok {
+			return t.Name
+		}
+	}
+	return ""
+}
+
+// isFrameworkTypeExpr reports whether typ is a (pointer to a) type of one of
+// the frameworks' packages, such as *kit.Router.
+func (s *routeScanner) isFrameworkTypeExpr(typ ast.Expr) bool {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	sel, ok := typ.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && s.isFrameworkPackage(pkg.Name)
+}
+
+// exprLocalType returns the package type of the value expr builds: &Server{},
+// Server{}, new(Server) or a call to a local function returning a *Server.
+func (s *routeScanner) exprLocalType(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.ParenExpr:
+		return s.exprLocalType(v.X)
+	case *ast.UnaryExpr:
+		if v.Op == token.AND {
+			return s.exprLocalType(v.X)
+		}
+	case *ast.CompositeLit:
+		if v.Type != nil {
+			return s.localTypeName(v.Type)
+		}
+	case *ast.CallExpr:
+		fn, ok := v.Fun.(*ast.Ident)
+		if !ok {
+			return ""
+		}
+		if fn.Name == "new" && len(v.Args) == 1 {
+			return s.localTypeName(v.Args[0])
+		}
+		if decl := s.funcs[fn.Name]; decl != nil && decl.Type.Results != nil && len(decl.Type.Results.List) > 0 {
+			return s.localTypeName(decl.Type.Results.List[0].Type)
+		}
+	}
+	return ""
+}
+
+// bindClosureRouters marks the router parameter of r.Route("/v1", func(r chi.Router) {...})
+// style closures when the call is made on a router.
+func (s *routeScanner) bindClosureRouters(call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !s.isRouterExpr(sel.X) {
+		return
+	}
+	for _, arg := range call.Args {
+		lit, ok := arg.(*ast.FuncLit)
+		if !ok || lit.Type.Params == nil || len(lit.Type.Params.List) == 0 {
+			continue
+		}
+		if !s.isRouterType(lit.Type.Params.List[0].Type) {
+			continue
+		}
+		for _, name := range lit.Type.Params.List[0].Names {
+			if name != nil && name.Name != "_" {
+				s.routers[name.Name] = struct{}{}
+			}
+		}
+	}
+}
+
+func (s *routeScanner) trackRouterAssign(stmt *ast.AssignStmt) {
+	if len(stmt.Lhs) == len(stmt.Rhs) {
+		for i, lhs := range stmt.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok {
+				if typ := s.exprLocalType(stmt.Rhs[i]); typ != "" {
+					s.varTypes[ident.Name] = typ
+				}
+				s.trackRecvType(ident, stmt.Rhs[i])
+			}
+			if s.isRouterExpr(stmt.Rhs[i]) {
+				s.bindRouter(lhs)
+			}
+		}
+		return
+	}
+	// app, err := newApp()
+	if len(stmt.Rhs) != 1 {
+		return
+	}
+	call, ok := stmt.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	if lhs, ok := stmt.Lhs[0].(*ast.Ident); ok {
+		s.trackRecvType(lhs, call)
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || s.funcs[ident.Name] == nil {
+		return
+	}
+	if lhs, ok := stmt.Lhs[0].(*ast.Ident); ok {
+		if typ := s.exprLocalType(call); typ != "" {
+			s.varTypes[lhs.Name] = typ
+		}
+	}
+	for i, lhs := range stmt.Lhs {
+		if s.returnsRouter(s.funcs[ident.Name].Type, i) {
+			s.bindRouter(lhs)
+		}
+	}
+}
+
+func (s *routeScanner) trackRouterValueSpec(spec *ast.ValueSpec) {
+	for i, name := range spec.Names {
+		typ, key := "", ""
+		if spec.Type != nil {
+			typ, key = s.localTypeName(spec.Type), s.typeKey(spec.Type)
+		} else if len(spec.Values) == len(spec.Names) {
+			typ, key = s.exprLocalType(spec.Values[i]), s.valueTypeKey(spec.Values[i])
+		}
+		if typ != "" && name.Name != "_" {
+			s.varTypes[name.Name] = typ
+		}
+		if key != "" && name.Name != "_" {
+			s.recvTypes[name.Name] = key
+		}
+		switch {
+		case spec.Type != nil && s.isRouterType(spec.Type):
+		case len(spec.Values) == len(spec.Names) && s.isRouterExpr(spec.Values[i]):
+		default:
+			continue
+		}
+		s.bindRouter(name)
+	}
+}
+
+// trackRecvType records the type of the value assigned to ident, or forgets
+// the variable's type when it cannot be told.
+func (s *routeScanner) trackRecvType(ident *ast.Ident, value ast.Expr) {
+	if ident.Name == "_" {
+		return
+	}
+	if key := s.valueTypeKey(value); key != "" {
+		s.recvTypes[ident.Name] = key
+	} else {
+		delete(s.recvTypes, ident.Name)
+	}
+}
+
+// bindRouter records a variable, or a struct field assigned through a selector
+// such as s.app = fiber.New(), as holding a router.
+func (s *routeScanner) bindRouter(target ast.Expr) {
+	switch t := target.(type) {
+	case *ast.Ident:
+		if t.Name != "_" {
+			s.routers[t.Name] = struct{}{}
+		}
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok && s.varTypes[x.Name] != "" {
+			s.pkg.addField(s.varTypes[x.Name], t.Sel.Name)
+		}
+	}
+}
+
+// isRouterField reports whether sel reads a router field of a variable whose
+// struct type is declared in the package, as in s.app.Get(...).
+func (s *routeScanner) isRouterField(sel *ast.SelectorExpr) bool {
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	typ := s.varTypes[x.Name]
+	if typ == "" {
+		return false
+	}
+	_, ok = s.pkg.fields[typ][sel.Sel.Name]
+	return ok
+}
+
+// packageRouters is what the files of a package declare at top level about
+// routers. Routes registered in routes.go on a field of a struct declared in
+// server.go depend on it, so it is collected from every file of the package
+// before the first one is scanned.
+type packageRouters struct {
+	types       map[string]struct{}            // types declared in the package
+	routerTypes map[string]struct{}            // package types that are or embed a router
+	vars        map[string]struct{}            // package-level router variables
+	varTypes    map[string]string              // package-level variables -> their package struct type
+	recvTypes   map[string]string              // package-level variables -> typeKey of their type
+	results     map[string]string              // functions -> typeKey of the type they return first
+	fields      map[string]map[string]struct{} // struct type -> fields holding a router
+}
+
+func (p *packageRouters) addField(typeName, field string) {
+	if p.fields[typeName] == nil {
+		p.fields[typeName] = make(map[string]struct{})
+	}
+	p.fields[typeName][field] = struct{}{}
+}
+
+// packageRouters returns the top-level routers of the package of file, parsed
+// from path, collecting them from the package's non-test files on first use.
+func (d *discovery) packageRouters(path string, file *ast.File) *packageRouters {
+	dir := filepath.Dir(path)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	key := funcKey(dir, file.Name.Name)
+	if pkg, ok := d.packages[key]; ok {
+		return pkg
+	}
+	pkg := &packageRouters{
+		types:       make(map[string]struct{}),
+		routerTypes: make(map[string]struct{}),
+		vars:        make(map[string]struct{}),
+		varTypes:    make(map[string]string),
+		recvTypes:   make(map[string]string),
+		results:     make(map[string]string),
+		fields:      make(map[string]map[string]struct{}),
+	}
+	d.packages[key] = pkg
+
+	files := []*ast.File{file}
+	paths := []string{path}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == filepath.Base(path) || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		other, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil || other.Name.Name != file.Name.Name {
+			continue
+		}
+		files = append(files, other)
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	// Types first: fields and variables of any file may use them.
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					pkg.types[spec.(*ast.TypeSpec).Name.Name] = struct{}{}
+				}
+			}
+		}
+	}
+	// Then results: variables of any file may be built by the functions.
+	scanners := make([]*routeScanner, len(files))
+	for i, f := range files {
+		scanners[i] = &routeScanner{
+			file:       f,
+			path:       paths[i],
+			imports:    fileImportAliases(f),
+			frameworks: d.frameworks,
+			funcs:      topLevelFuncs(f),
+			pkg:        pkg,
+			routers:    pkg.vars,
+			varTypes:   pkg.varTypes,
+			recvTypes:  pkg.recvTypes,
+			discovery:  d,
+			consts:     d.consts,
+		}
+		for name, fn := range scanners[i].funcs {
+			if fn.Type.Results != nil && len(fn.Type.Results.List) > 0 {
+				if key := scanners[i].typeKey(fn.Type.Results.List[0].Type); key != "" {
+					pkg.results[name] = key
+				}
+			}
+		}
+	}
+	// Router types: those with route methods, then those embedding a router
+	// type, which may embed one another across files.
+	for _, s := range scanners {
+		for _, decl := range s.file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil && isRouteMethod(fn.Name.Name, fn.Type) {
+				if name := s.localTypeName(fn.Recv.List[0].Type); name != "" {
+					pkg.routerTypes[name] = struct{}{}
+				}
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, s := range scanners {
+			for _, decl := range s.file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					if _, ok := pkg.routerTypes[ts.Name.Name]; !ok && s.embedsRouter(ts.Type) {
+						pkg.routerTypes[ts.Name.Name] = struct{}{}
+						changed = true
+					}
+				}
+			}
+		}
+	}
+	for _, s := range scanners {
+		s.collectPackageRouters()
+	}
+	return pkg
+}
+
+// packageAt returns the top-level routers of the package in dir, or nil when
+// dir holds no Go files.
+func (d *discovery) packageAt(dir string) *packageRouters {
+	if pkg, ok := d.dirs[dir]; ok {
+		return pkg
+	}
+	var pkg *packageRouters
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution); err == nil {
+			pkg = d.packageRouters(path, file)
+			break
+		}
+	}
+	d.dirs[dir] = pkg
+	return pkg
+}
+
+// collectPackageRouters records the package-level router variables and the
+// struct fields declared with a router type of s.file.
+func (s *routeScanner) collectPackageRouters() {
+	for _, decl := range s.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			switch sp := spec.(type) {
+			case *ast.ValueSpec:
+				s.trackRouterValueSpec(sp)
+			case *ast.TypeSpec:
+				st, ok := sp.Type.(*ast.StructType)
+				if !ok || st.Fields == nil {
+					continue
+				}
+				for _, field := range st.Fields.List {
+					if !s.isRouterType(field.Type) {
+						continue
+					}
+					for _, name := range field.Names {
+						s.pkg.addField(sp.Name.Name, name.Name)
+					}
+				}
+			}
+		}
+	}
+}
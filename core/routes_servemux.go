@@ -0,0 +1,104 @@
+package core
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// serveMuxRouteCall matches net/http registrations such as
+// mux.HandleFunc("GET /users/{id}", h.getUser) and http.Handle("/health", h).
+func serveMuxRouteCall(call *ast.CallExpr, str stringResolver) (RouteCall, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return RouteCall{}, false
+	}
+	if sel.Sel.Name != "HandleFunc" && sel.Sel.Name != "Handle" {
+		return RouteCall{}, false
+	}
+	if len(call.Args) != 2 {
+		return RouteCall{}, false
+	}
+	pattern, ok := str(call.Args[0])
+	if !ok {
+		return RouteCall{}, false
+	}
+	method, host, path, ok := parseServeMuxPattern(pattern)
+	if !ok {
+		return RouteCall{}, false
+	}
+	return RouteCall{
+		Method:   method,
+		Path:     path,
+		Receiver: sel.X,
+		Handler:  call.Args[1],
+		host:     host,
+	}, true
+}
+
+// parseServeMuxPattern splits a Go 1.22 ServeMux pattern ("[METHOD ][HOST]/[PATH]")
+// into its parts. The method is kept as written, since ServeMux matches it
+// case-sensitively. Patterns without a method match every verb; they are
+// documented as GET. The host is reported by the scanner and left out of the path, and the
+// {$} end anchor is dropped, because neither has an OpenAPI equivalent.
+func parseServeMuxPattern(pattern string) (method, host, path string, ok bool) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return "", "", "", false
+	}
+	method = "GET"
+	if idx := strings.IndexAny(pattern, " \t"); idx >= 0 {
+		method = pattern[:idx]
+		pattern = strings.TrimLeft(pattern[idx:], " \t")
+	}
+	slash := strings.Index(pattern, "/")
+	if slash < 0 {
+		return "", "", "", false
+	}
+	host = pattern[:slash]
+	path = pattern[slash:]
+	if strings.HasSuffix(path, "/{$}") {
+		path = strings.TrimSuffix(path, "{$}")
+	}
+	return method, host, path, true
+}
+
+// unwrapHandlerConversion returns the function wrapped by adapter conversions
+// like http.HandlerFunc(fn), or nil when call is not such a conversion.
+func unwrapHandlerConversion(call *ast.CallExpr) ast.Expr {
+	if call == nil || len(call.Args) != 1 {
+		return nil
+	}
+	name := ""
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		name = fn.Name
+	case *ast.SelectorExpr:
+		name = fn.Sel.Name
+	}
+	if name != "HandlerFunc" {
+		return nil
+	}
+	switch call.Args[0].(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		return call.Args[0]
+	}
+	return nil
+}
+
+// bracedParamName extracts the parameter name from ServeMux/chi style segments
+// such as {id}, {path...} or {id:[0-9]+}.
+func bracedParamName(segment string) (string, bool) {
+	if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+		return "", false
+	}
+	name := strings.TrimSpace(segment[1 : len(segment)-1])
+	if idx := strings.Index(name, ":"); idx >= 0 {
+		name = name[:idx]
+	}
+	name = strings.TrimSuffix(name, "...")
+	name = strings.TrimSpace(name)
+	if name == "" || name == "$" {
+		return "", false
+	}
+	return name, true
+}
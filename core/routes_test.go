@@ -0,0 +1,344 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseServeMuxPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		method  string
+		host    string
+		path    string
+	}{
+		{"/health", "GET", "", "/health"},
+		{"GET /users/{id}", "GET", "", "/users/{id}"},
+		{"post /users", "post", "", "/users"},
+		{"DELETE api.example.com/users/{id}", "DELETE", "api.example.com", "/users/{id}"},
+		{"GET /files/{path...}", "GET", "", "/files/{path...}"},
+		{"GET /{$}", "GET", "", "/"},
+		{"GET /posts/{$}", "GET", "", "/posts/"},
+	}
+	for _, tt := range tests {
+		method, host, path, ok := parseServeMuxPattern(tt.pattern)
+		if !ok {
+			t.Fatalf("parseServeMuxPattern(%q) not ok", tt.pattern)
+		}
+		if method != tt.method || host != tt.host || path != tt.path {
+			t.Fatalf("parseServeMuxPattern(%q) = (%q, %q, %q), want (%q, %q, %q)", tt.pattern, method, host, path, tt.method, tt.host, tt.path)
+		}
+	}
+
+	if _, _, _, ok := parseServeMuxPattern("GET users"); ok {
+		t.Fatalf("expected pattern without a path to be rejected")
+	}
+}
+
+func TestFindRoutesRejectsNonRouterRegisterParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.go")
+	writeTestFile(t, path, `package api
+
+import "github.com/gofiber/fiber/v2"
+
+type Store struct {
+	data map[string]string
+}
+
+func (s *Store) Get(key string, dst interface{}) bool { return false }
+
+type Router interface {
+	fiber.Router
+}
+
+func RegisterCache(store *Store) {
+	var v string
+	store.Get("k", &v)
+}
+
+func RegisterRoutes(r Router, store *Store) {
+	r.Get("/health", health)
+}
+`)
+
+	routes, diagnostics, err := FindRoutesWithDiagnostics(path)
+	if err != nil {
+		t.Fatalf("FindRoutesWithDiagnostics: %v", err)
+	}
+	if len(routes) != 1 || routes[0].Path != "/health" {
+		t.Fatalf("routes = %v, want only GET /health", routes)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Line != 17 || !strings.Contains(diagnostics[0].Message, "receiver store is not a known router") {
+		t.Fatalf("diagnostics = %v, want the call on store", diagnostics)
+	}
+}
+
+func TestFindRoutesReportsServeMuxHost(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.go")
+	writeTestFile(t, path, `package api
+
+import "net/http"
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET api.example.com/users", listUsers)
+	mux.HandleFunc("/health", health)
+}
+`)
+
+	routes, diagnostics, err := FindRoutesWithDiagnostics(path)
+	if err != nil {
+		t.Fatalf("FindRoutesWithDiagnostics: %v", err)
+	}
+	if len(routes) != 2 || routes[0].Path != "/users" || routes[1].Method != "GET" {
+		t.Fatalf("unexpected routes %#v", routes)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Line != 6 || !strings.Contains(diagnostics[0].Message, "host api.example.com is not documented") {
+		t.Fatalf("diagnostics = %v, want the host of GET /users", diagnostics)
+	}
+}
+
+func TestFindRoutesSkipsLowerCaseServeMuxMethods(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.go")
+	writeTestFile(t, path, `package api
+
+import "net/http"
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("post /users", createUser)
+	mux.HandleFunc("POST /orders", createOrder)
+}
+`)
+
+	routes, diagnostics, err := FindRoutesWithDiagnostics(path)
+	if err != nil {
+		t.Fatalf("FindRoutesWithDiagnostics: %v", err)
+	}
+	if len(routes) != 1 || routes[0].Method != "POST" || routes[0].Path != "/orders" {
+		t.Fatalf("routes = %v, want only POST /orders", routes)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Line != 6 || !strings.Contains(diagnostics[0].Message, "method post is not upper case") {
+		t.Fatalf("diagnostics = %v, want the post pattern", diagnostics)
+	}
+}
+
+func TestFindRoutesIgnoresGroupMiddlewareLiterals(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.go")
+	writeTestFile(t, path, `package api
+
+import "github.com/gin-gonic/gin"
+
+func Register(r *gin.Engine) {
+	v1 := r.Group("/v1", func(c *gin.Context) {
+		for _, t := range trackers {
+			t.Track(c)
+		}
+	})
+	v1.GET("/users", listUsers)
+}
+`)
+
+	routes, diagnostics, err := FindRoutesWithDiagnostics(path)
+	if err != nil {
+		t.Fatalf("FindRoutesWithDiagnostics: %v", err)
+	}
+	if len(routes) != 1 || routes[0].Method != "GET" || routes[0].Path != "/v1/users" {
+		t.Fatalf("routes = %v, want only GET /v1/users", routes)
+	}
+	if len(diagnostics) != 0 {
+		t.Fatalf("diagnostics = %v, want none: c is not a router", diagnostics)
+	}
+}
+
+func TestFindRoutesReportsUnknownMethodReceivers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.go")
+	writeTestFile(t, path, `package api
+
+import "github.com/gofiber/fiber/v2"
+
+type module interface {
+	Register(r fiber.Router)
+}
+
+func Setup(app *fiber.App, modules []module) {
+	for _, m := range modules {
+		m.Register(app.Group("/modules"))
+	}
+}
+`)
+
+	_, diagnostics, err := FindRoutesWithDiagnostics(path)
+	if err != nil {
+		t.Fatalf("FindRoutesWithDiagnostics: %v", err)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Line != 11 || !strings.Contains(diagnostics[0].Message, "passed to m.Register is not followed") {
+		t.Fatalf("diagnostics = %v, want the call on m", diagnostics)
+	}
+}
+
+func TestNormalizeOpenAPIPathBraced(t *testing.T) {
+	tests := map[string]string{
+		"/files/{path...}":     "/files/{path}",
+		"/users/{id:[0-9]+}":   "/users/{id}",
+		"/users/:id/posts/{n}": "/users/{id}/posts/{n}",
+	}
+	for in, want := range tests {
+		if got := normalizeOpenAPIPath(in); got != want {
+			t.Fatalf("normalizeOpenAPIPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+	params := extractPathParams("/files/{path...}/{id:[0-9]+}")
+	if len(params) != 2 || params[0] != "path" || params[1] != "id" {
+		t.Fatalf("unexpected params %#v", params)
+	}
+}
+
+func TestFiberRouteParams(t *testing.T) {
+	tests := map[string]string{
+		"/users/:id<int;min(1)>":       "/users/{id}",
+		"/files/:name?":                "/files/{name}",
+		"/x/:v<regex(a>b;c)>?":         "/x/{v}",
+		"/assets/+":                    "/assets/{wildcard}",
+		"/d/:day<datetime(2006\\-01)>": "/d/{day}",
+	}
+	for in, want := range tests {
+		if got := normalizeOpenAPIPath(in); got != want {
+			t.Fatalf("normalizeOpenAPIPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	params := routePathParams("/x/:v<regex(a>b;c);maxLen(8)>?")
+	if len(params) != 1 || params[0].Pattern != "a>b;c" || params[0].MaxLength == nil || *params[0].MaxLength != 8 {
+		t.Fatalf("unexpected params %#v", params)
+	}
+
+	variants := expandOptionalSegments("/:a?/:b<int>?/c")
+	want := []string{"/c", "/:a/c", "/:a/:b<int>/c"}
+	if strings.Join(variants, " ") != strings.Join(want, " ") {
+		t.Fatalf("expandOptionalSegments = %q, want %q", variants, want)
+	}
+}
+
+func TestDefaultImportAlias(t *testing.T) {
+	tests := map[string]string{
+		"github.com/go-chi/chi/v5":     "chi",
+		"github.com/gofiber/fiber/v2":  "fiber",
+		"gopkg.in/yaml.v3":             "yaml",
+		"github.com/gin-gonic/gin":     "gin",
+		"example.com/project/handlers": "handlers",
+	}
+	for in, want := range tests {
+		if got := defaultImportAlias(in); got != want {
+			t.Fatalf("defaultImportAlias(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFindRoutesRejectsNonRouterReceivers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.go")
+	writeTestFile(t, path, `package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Server struct {
+	app   *fiber.App
+	cache *Cache
+}
+
+type Cache struct {
+	app *store.Client
+}
+
+func (c *Cache) warm() {
+	c.app.Get("users", fill)
+}
+
+func New() *Server {
+	app := fiber.New()
+	v1 := app.Group("/v1")
+	v1.Get("/users", listUsers)
+
+	var dst string
+	cache := NewCache()
+	cache.Get("key", &dst)
+	cfg.Get("a", "b")
+	return &Server{app: app}
+}
+
+func (s *Server) routes() {
+	s.app.Post("/orders", createOrder)
+	s.cache.Get("orders", createOrder)
+}
+
+type Router interface {
+	Get(path string, handlers ...fiber.Handler) fiber.Router
+}
+
+func Register(router Router) {
+	router.Get("/health", health)
+}
+
+func RegisterMetrics(reg *prometheus.Registry) {
+	reg.Get("/metrics", metrics)
+}
+`)
+
+	routes, diagnostics, err := FindRoutesWithDiagnostics(path)
+	if err != nil {
+		t.Fatalf("FindRoutesWithDiagnostics: %v", err)
+	}
+	var got []string
+	for _, r := range routes {
+		got = append(got, r.Method+" "+r.Path)
+	}
+	want := []string{"GET /v1/users", "POST /orders", "GET /health"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("routes = %v, want %v", got, want)
+	}
+	if len(diagnostics) != 5 {
+		t.Fatalf("diagnostics = %v, want 5", diagnostics)
+	}
+	if d := diagnostics[0]; d.Line != 18 || !strings.Contains(d.Message, "receiver c.app is not a known router") {
+		t.Fatalf("unexpected diagnostic %s", d)
+	}
+	if d := diagnostics[1]; d.Line != 28 || !strings.Contains(d.Message, "receiver cache is not a known router") {
+		t.Fatalf("unexpected diagnostic %s", d)
+	}
+}
+
+func TestFindRoutesMiddleware(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.go")
+	writeTestFile(t, path, `package api
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+func Register(r chi.Router) {
+	r.Get("/public", public)
+	r.Group(func(r chi.Router) {
+		r.Use(jwtauth.Authenticator)
+		r.Get("/private", private)
+	})
+	r.With(requireAuth("admin")).Get("/admin", admin)
+	r.Get("/after", after)
+}
+`)
+
+	routes, err := FindRoutes(path)
+	if err != nil {
+		t.Fatalf("FindRoutes: %v", err)
+	}
+	var got []string
+	for _, r := range routes {
+		got = append(got, r.Path+"="+strings.Join(r.Middleware, "+"))
+	}
+	want := []string{"/public=", "/private=jwtauth.Authenticator", "/admin=requireAuth", "/after="}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("middleware = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/chirouter
+
+go 1.22
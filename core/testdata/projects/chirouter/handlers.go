@@ -0,0 +1,87 @@
+package chirouter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/health", health)
+
+	r.Route("/v1", func(r chi.Router) {
+		r.Route("/users", func(r chi.Router) {
+			r.Get("/", listUsers)
+			r.With(paginate).Get("/search", searchUsers)
+			r.Route("/{userID}", func(r chi.Router) {
+				r.Get("/", getUser)
+				r.Method(http.MethodDelete, "/", http.HandlerFunc(deleteUser))
+			})
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(authenticate)
+			r.MethodFunc("POST", "/users", createUser)
+		})
+		r.Mount("/admin", adminRouter())
+	})
+
+	reports := chi.NewRouter()
+	reports.Get("/daily", dailyReport)
+	r.Mount("/reports", reports)
+	return r
+}
+
+func adminRouter() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/stats", adminStats)
+	return r
+}
+
+func paginate(next http.Handler) http.Handler     { return next }
+func authenticate(next http.Handler) http.Handler { return next }
+
+func health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	users := []User{}
+	json.NewEncoder(w).Encode(users)
+}
+
+func searchUsers(w http.ResponseWriter, r *http.Request) {
+	users := []User{}
+	_ = r.URL.Query().Get("q")
+	json.NewEncoder(w).Encode(users)
+}
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	user := User{ID: chi.URLParam(r, "userID")}
+	json.NewEncoder(w).Encode(user)
+}
+
+func deleteUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func createUser(w http.ResponseWriter, r *http.Request) {
+	var req CreateUser
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	user := User{Name: req.Name}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+func adminStats(w http.ResponseWriter, r *http.Request) {
+	stats := Stats{}
+	json.NewEncoder(w).Encode(stats)
+}
+
+func dailyReport(w http.ResponseWriter, r *http.Request) {
+	http.ServeFile(w, r, "daily.csv")
+}
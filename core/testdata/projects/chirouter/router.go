@@ -0,0 +1,14 @@
+package chirouter
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CreateUser struct {
+	Name string `json:"name"`
+}
+
+type Stats struct {
+	Users int `json:"users"`
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/constpaths
+
+go 1.22
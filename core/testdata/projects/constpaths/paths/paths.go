@@ -0,0 +1,8 @@
+package paths
+
+const (
+	API = "/api"
+	V1  = API + "/v1"
+)
+
+const Users = "/users"
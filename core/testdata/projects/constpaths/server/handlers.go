@@ -0,0 +1,58 @@
+package server
+
+import "example.com/docoo/constpaths/paths"
+
+const (
+	itemsPath = "/items"
+	itemPath  = itemsPath + "/:id"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Item struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func Register(app *App) {
+	const healthPath = "/health"
+	app.Get(healthPath, healthHandler)
+
+	v1 := app.Group(paths.V1)
+	v1.Get(paths.Users, listUsers)
+	v1.Get(paths.Users+"/:id", getUser)
+	v1.Get(itemPath, getItem)
+	v1.Post(itemsPath, createItem)
+}
+
+func healthHandler(c *Ctx) error {
+	return c.JSON(map[string]interface{}{
+		"status": "ok",
+	})
+}
+
+func listUsers(c *Ctx) error {
+	users := []User{}
+	return c.JSON(users)
+}
+
+func getUser(c *Ctx) error {
+	var user User
+	return c.JSON(user)
+}
+
+func getItem(c *Ctx) error {
+	var item Item
+	return c.JSON(item)
+}
+
+func createItem(c *Ctx) error {
+	var item Item
+	if err := c.BodyParser(&item); err != nil {
+		return err
+	}
+	return c.Status(201).JSON(item)
+}
@@ -0,0 +1,13 @@
+package server
+
+type App struct{}
+
+func (a *App) Get(path string, handler interface{})  {}
+func (a *App) Post(path string, handler interface{}) {}
+func (a *App) Group(prefix string) *App              { return a }
+
+type Ctx struct{}
+
+func (c *Ctx) BodyParser(v interface{}) error { return nil }
+func (c *Ctx) JSON(value interface{}) error   { return nil }
+func (c *Ctx) Status(code int) *Ctx           { return c }
@@ -0,0 +1,3 @@
+module example.com/docoo/doccomments
+
+go 1.22
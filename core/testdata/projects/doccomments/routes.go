@@ -0,0 +1,48 @@
+package doccomments
+
+import "github.com/gofiber/fiber/v2"
+
+// Product is an item of the catalogue.
+type Product struct {
+	// SKU identifies the product across warehouses.
+	SKU   string `json:"sku"`
+	Price Money  `json:"price"` // Price including VAT.
+	// Supplier delivers the product.
+	Supplier Supplier `json:"supplier"`
+	// LegacyID was used before SKUs.
+	//
+	// Deprecated: use SKU.
+	LegacyID int `json:"legacy_id,omitempty"`
+	Stock    int `json:"stock"`
+}
+
+type (
+	// Money is an amount in cents.
+	Money int64
+
+	Supplier struct {
+		Name string `json:"name"`
+	} // Supplier of a product.
+)
+
+// LegacyProduct is the v1 representation.
+//
+// Deprecated: use Product.
+type LegacyProduct struct {
+	ID int `json:"id"`
+}
+
+func Register(app *fiber.App) {
+	app.Get("/products/:sku", getProduct)
+	app.Get("/v1/products/:id", getLegacyProduct)
+}
+
+func getProduct(c *fiber.Ctx) error {
+	var product Product
+	return c.JSON(product)
+}
+
+func getLegacyProduct(c *fiber.Ctx) error {
+	var product LegacyProduct
+	return c.JSON(product)
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/echoapp
+
+go 1.22
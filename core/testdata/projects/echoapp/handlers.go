@@ -0,0 +1,79 @@
+package echoapp
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func Register(e *echo.Echo) {
+	e.GET("/health", health)
+
+	api := e.Group("/api", authRequired)
+	api.GET("/items", listItems)
+	api.GET("/items/:id", getItem, logRequest)
+	api.POST("/items", createItem)
+	api.Add(http.MethodDelete, "/items/:id", deleteItem)
+	api.GET("/items/:id/export", exportItem)
+	api.GET("/items/:id/page", itemPage)
+	api.GET("/items/:id/raw", rawItem)
+	api.GET("/files/*", downloadFile)
+}
+
+func authRequired(next echo.HandlerFunc) echo.HandlerFunc { return next }
+
+func logRequest(next echo.HandlerFunc) echo.HandlerFunc { return next }
+
+func health(c echo.Context) error {
+	return c.String(http.StatusOK, "ok")
+}
+
+func listItems(c echo.Context) error {
+	search := c.QueryParam("q")
+	_ = search
+	items := []Item{}
+	return c.JSON(http.StatusOK, items)
+}
+
+func getItem(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "item not found")
+	}
+	item := Item{ID: id}
+	return c.JSON(http.StatusOK, item)
+}
+
+func createItem(c echo.Context) error {
+	var req CreateItem
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	item := Item{Name: req.Name}
+	return c.JSON(http.StatusCreated, item)
+}
+
+func exportItem(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": "missing id"})
+	}
+	item := Item{ID: id}
+	return c.XMLPretty(http.StatusOK, item, "  ")
+}
+
+func itemPage(c echo.Context) error {
+	return c.HTML(http.StatusOK, "<h1>"+c.Param("id")+"</h1>")
+}
+
+func rawItem(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, []byte(`{"id":"`+c.Param("id")+`"}`))
+}
+
+func deleteItem(c echo.Context) error {
+	return c.NoContent(http.StatusNoContent)
+}
+
+func downloadFile(c echo.Context) error {
+	return c.File("./files/" + c.Param("*"))
+}
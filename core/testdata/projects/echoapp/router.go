@@ -0,0 +1,10 @@
+package echoapp
+
+type Item struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CreateItem struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/embedded
+
+go 1.22
@@ -0,0 +1,53 @@
+package models
+
+import "example.com/docoo/embedded/shared"
+
+type BaseModel struct {
+	ID string `json:"id"`
+	shared.Timestamps
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by"`
+	Note      string `json:"note"`
+}
+
+type Labels []string
+
+type meta struct {
+	Version int `json:"version"`
+}
+
+type Named struct {
+	Name string `json:"Name,omitempty"`
+	Code string
+}
+
+type Titled struct {
+	Name string
+	Code string
+}
+
+type Owner struct {
+	ID string `json:"owner_id"`
+}
+
+// UserResponse inherits id and timestamps from BaseModel. Name is declared on
+// both Named (tagged) and Titled at the same depth, so the tagged one wins;
+// Code is ambiguous and dropped; Note is shadowed by the outer field.
+type UserResponse struct {
+	BaseModel
+	*Audit
+	Labels
+	meta
+	Named
+	Titled
+	Owner  `json:"owner"`
+	Hidden `json:"-"`
+	Email  string `json:"email"`
+	Note   int    `json:"note"`
+}
+
+type Hidden struct {
+	Secret string `json:"secret"`
+}
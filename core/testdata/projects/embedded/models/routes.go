@@ -0,0 +1,15 @@
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /users/{id}", getUser)
+}
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	var user UserResponse
+	json.NewEncoder(w).Encode(user)
+}
@@ -0,0 +1,8 @@
+package shared
+
+import "time"
+
+type Timestamps struct {
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/enums
+
+go 1.22
@@ -0,0 +1,22 @@
+package orders
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Order struct {
+	ID       string      `json:"id"`
+	Status   OrderStatus `json:"status"`
+	Priority Priority    `json:"priority"`
+	Channel  Channel     `json:"channel"`
+}
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /orders", listOrders)
+}
+
+func listOrders(w http.ResponseWriter, r *http.Request) {
+	orders := []Order{{ID: "1", Status: StatusPending, Priority: PriorityNormal, Channel: ChannelWeb}}
+	json.NewEncoder(w).Encode(orders)
+}
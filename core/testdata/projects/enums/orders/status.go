@@ -0,0 +1,28 @@
+package orders
+
+type OrderStatus string
+
+const (
+	StatusPending  OrderStatus = "pending"
+	StatusPaid     OrderStatus = "paid"
+	StatusCanceled OrderStatus = "canceled"
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityNormal
+	_
+	PriorityUrgent
+)
+
+type Channel string
+
+const (
+	ChannelWeb    = Channel("web")
+	ChannelMobile = Channel("mobile")
+)
+
+// defaultStatus is not a member of the enum.
+const defaultStatus = "pending"
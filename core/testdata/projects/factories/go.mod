@@ -0,0 +1,3 @@
+module example.com/docoo/factories
+
+go 1.22
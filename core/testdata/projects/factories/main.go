@@ -0,0 +1,52 @@
+package main
+
+import (
+	"example.com/docoo/factories/users"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"net/http"
+)
+
+type Health struct {
+	Status string `json:"status"`
+}
+
+type statusHandler struct {
+	version string
+}
+
+func main() {
+	app := fiber.New()
+	svc := users.NewService()
+	h := &statusHandler{version: "1.0"}
+
+	app.Get("/users", users.List(svc))
+	app.Post("/users", users.Create(svc))
+	app.Get("/health", health("ok"))
+	app.Get("/status", h.Status())
+	app.Get("/metrics", adaptor.HTTPHandler(http.NotFoundHandler()))
+
+	_ = app.Listen(":3000")
+}
+
+// health reports the service health.
+func health(status string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		resp := Health{Status: status}
+		return c.JSON(resp)
+	}
+}
+
+type Status struct {
+	Version string `json:"version"`
+}
+
+// Status returns the running version.
+// @Tags status
+func (h *statusHandler) Status() fiber.Handler {
+	handler := func(c *fiber.Ctx) error {
+		resp := Status{Version: h.version}
+		return c.JSON(resp)
+	}
+	return handler
+}
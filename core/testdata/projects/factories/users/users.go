@@ -0,0 +1,41 @@
+package users
+
+import "github.com/gofiber/fiber/v2"
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Service struct {
+	users []User
+}
+
+func NewService() *Service {
+	return &Service{}
+}
+
+// List builds the handler listing users.
+func List(svc *Service) fiber.Handler {
+	// @Summary List users
+	// @Tags users
+	return func(c *fiber.Ctx) error {
+		if c.Query("active") == "true" {
+			active := []User{}
+			return c.JSON(active)
+		}
+		return c.JSON(svc.users)
+	}
+}
+
+func Create(svc *Service) fiber.Handler {
+	// Create adds a user.
+	return func(c *fiber.Ctx) error {
+		var user User
+		if err := c.BodyParser(&user); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		svc.users = append(svc.users, user)
+		return c.Status(fiber.StatusCreated).JSON(user)
+	}
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/fiberheaders
+
+go 1.22
@@ -0,0 +1,59 @@
+package fiberheaders
+
+import "github.com/gofiber/fiber/v2"
+
+type Session struct {
+	ID string `json:"id"`
+}
+
+type ClientHeaders struct {
+	RequestID string   `reqHeader:"X-Request-ID"`
+	Languages []string `reqHeader:"Accept-Language"`
+	Internal  string   `reqHeader:"-"`
+}
+
+type Prefs struct {
+	Theme string `cookie:"theme"`
+	Lang  string `cookie:"lang"`
+}
+
+func Register(app *fiber.App) {
+	app.Get("/session", getSession)
+	app.Get("/trace", trace)
+	app.Get("/client", client)
+	app.Get("/prefs", prefs)
+}
+
+// @Param X-Tenant header string true "tenant id"
+func getSession(c *fiber.Ctx) error {
+	tenant := c.Get("x-tenant")
+	token := c.Cookies("session")
+	_ = c.Get(fiber.HeaderAuthorization)
+	_ = c.Get("Content-Type")
+	session := Session{ID: tenant + token}
+	return c.JSON(session)
+}
+
+func trace(c *fiber.Ctx) error {
+	headers := c.GetReqHeaders()
+	if _, ok := headers["Traceparent"]; !ok {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	return c.SendString(c.GetReqHeaders()["Tracestate"][0])
+}
+
+func client(c *fiber.Ctx) error {
+	var h ClientHeaders
+	if err := c.ReqHeaderParser(&h); err != nil {
+		return err
+	}
+	return c.SendString(h.RequestID)
+}
+
+func prefs(c *fiber.Ctx) error {
+	p := new(Prefs)
+	if err := c.CookieParser(p); err != nil {
+		return err
+	}
+	return c.SendString(p.Theme)
+}
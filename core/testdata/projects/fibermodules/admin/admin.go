@@ -0,0 +1,18 @@
+package admin
+
+import "github.com/gofiber/fiber/v2"
+
+type Stats struct {
+	Users int `json:"users"`
+}
+
+func New() *fiber.App {
+	app := fiber.New()
+	app.Get("/stats", stats)
+	return app
+}
+
+func stats(c *fiber.Ctx) error {
+	var s Stats
+	return c.JSON(s)
+}
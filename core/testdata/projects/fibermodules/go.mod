@@ -0,0 +1,3 @@
+module example.com/docoo/fibermodules
+
+go 1.22
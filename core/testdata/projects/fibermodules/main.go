@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"example.com/docoo/fibermodules/admin"
+	"example.com/docoo/fibermodules/users"
+)
+
+func main() {
+	app := fiber.New()
+
+	api := app.Group("/api/v1")
+	users.Register(api)
+	registerShop(api.Group("/shop"))
+
+	app.Mount("/admin", admin.New())
+
+	app.Route("/v2", func(v2 fiber.Router) {
+		users.Register(v2)
+	})
+
+	_ = app.Listen(":3000")
+}
@@ -0,0 +1,23 @@
+package orders
+
+import "github.com/gofiber/fiber/v2"
+
+type Order struct {
+	ID    string  `json:"id"`
+	Total float64 `json:"total"`
+}
+
+type Handler struct{}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+func (h *Handler) Register(r fiber.Router) {
+	r.Get("/orders", h.listOrders)
+}
+
+func (h *Handler) listOrders(c *fiber.Ctx) error {
+	orders := []Order{}
+	return c.JSON(orders)
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"example.com/docoo/fibermodules/orders"
+)
+
+func registerShop(r fiber.Router) {
+	h := orders.NewHandler()
+	h.Register(r)
+}
@@ -0,0 +1,26 @@
+package users
+
+import "github.com/gofiber/fiber/v2"
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func Register(r fiber.Router) {
+	r.Get("/users", listUsers)
+	r.Post("/users", createUser)
+}
+
+func listUsers(c *fiber.Ctx) error {
+	users := []User{}
+	return c.JSON(users)
+}
+
+func createUser(c *fiber.Ctx) error {
+	var user User
+	if err := c.BodyParser(&user); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(user)
+}
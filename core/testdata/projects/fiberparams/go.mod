@@ -0,0 +1,3 @@
+module example.com/docoo/fiberparams
+
+go 1.22
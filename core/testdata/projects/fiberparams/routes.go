@@ -0,0 +1,111 @@
+package fiberparams
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ItemRef struct {
+	Org string `params:"org"`
+	Seq int    `params:"seq"`
+}
+
+type SearchFilter struct {
+	IDs      []int   `query:"ids"`
+	Active   *bool   `query:"active"`
+	MinPrice float64 `query:"min_price"`
+	Internal string  `query:"-"`
+}
+
+type Report struct {
+	Day   string `json:"day"`
+	Total int    `json:"total"`
+}
+
+func Register(app *fiber.App) {
+	app.Get("/users/:id<int;min(1)>", getUser)
+	app.Get("/users/:id<int>/avatar/:size?", getAvatar)
+	app.Get("/orders/:ref<guid>", getOrder)
+	app.Get("/tags/:tag<alpha;betweenLen(2,20)>", getTag)
+	app.Get("/pages/:page<range(1,100)>", getPage)
+	app.Get("/codes/:code<regex(^[A-Z]{3}$)>", getCode)
+	app.Get("/reports/:day<datetime(2006\\-01\\-02)>", getReport)
+	app.Get("/flags/:on<bool>", getFlag)
+	app.Get("/assets/+", getAsset)
+	app.Get("/items/:id<min(1)>", getItem)
+	app.Get("/accounts/:org/items/:seq", getAccountItem)
+	app.Get("/search", search)
+}
+
+func getUser(c *fiber.Ctx) error {
+	var user User
+	return c.JSON(user)
+}
+
+func getAvatar(c *fiber.Ctx) error {
+	return c.SendFile("avatar.png")
+}
+
+func getOrder(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func getTag(c *fiber.Ctx) error {
+	return c.SendString(c.Params("tag"))
+}
+
+func getPage(c *fiber.Ctx) error {
+	return c.SendString(c.Params("page"))
+}
+
+func getCode(c *fiber.Ctx) error {
+	return c.SendString(c.Params("code"))
+}
+
+func getReport(c *fiber.Ctx) error {
+	var report Report
+	return c.JSON(report)
+}
+
+func getFlag(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func getAsset(c *fiber.Ctx) error {
+	return c.SendFile(c.Params("+"))
+}
+
+func getItem(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return err
+	}
+	return c.SendString(strconv.Itoa(id))
+}
+
+func getAccountItem(c *fiber.Ctx) error {
+	var ref ItemRef
+	if err := c.ParamsParser(&ref); err != nil {
+		return err
+	}
+	return c.SendString(ref.Org)
+}
+
+func search(c *fiber.Ctx) error {
+	sort := c.Query("sort", "asc")
+	limit := c.QueryInt("limit", 20)
+	offset := c.QueryInt("offset", -1)
+	verbose := c.QueryBool("verbose", true)
+	filter := new(SearchFilter)
+	if err := c.QueryParser(filter); err != nil {
+		return err
+	}
+	_, _, _ = limit, offset, verbose
+	return c.SendString(sort)
+}
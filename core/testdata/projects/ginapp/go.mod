@@ -0,0 +1,3 @@
+module example.com/docoo/ginapp
+
+go 1.22
@@ -0,0 +1,100 @@
+package ginapp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Register(router *gin.Engine) {
+	router.GET("/health", health)
+
+	api := router.Group("/api", authRequired)
+	api.GET("/items", listItems)
+	api.GET("/items/:id", getItem)
+	api.POST("/items", createItem)
+	api.PUT("/items/:id", replaceItem)
+	api.Handle(http.MethodDelete, "/items/:id", deleteItem)
+	api.GET("/items/:id/export", exportItem)
+	api.GET("/manifest", manifest)
+	api.GET("/files/*filepath", downloadFile)
+	api.POST("/upload", uploadFile)
+}
+
+func authRequired(c *gin.Context) { c.Next() }
+
+func health(c *gin.Context) {
+	c.String(http.StatusOK, "ok")
+}
+
+func listItems(c *gin.Context) {
+	var filter ItemFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page := c.DefaultQuery("page", "1")
+	_ = page
+	items := []Item{}
+	c.JSON(http.StatusOK, items)
+}
+
+func getItem(c *gin.Context) {
+	item := Item{ID: c.Param("id")}
+	c.JSON(http.StatusOK, item)
+}
+
+func createItem(c *gin.Context) {
+	var req CreateItem
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	item := Item{Name: req.Name}
+	c.JSON(http.StatusCreated, item)
+}
+
+func replaceItem(c *gin.Context) {
+	var req CreateItem
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	item := Item{ID: c.Param("id"), Name: req.Name}
+	c.Status(http.StatusCreated)
+	json.NewEncoder(c.Writer).Encode(item)
+}
+
+func exportItem(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing id"})
+		return
+	}
+	item := Item{ID: id}
+	c.XML(http.StatusOK, item)
+}
+
+func manifest(c *gin.Context) {
+	m := Manifest{Version: "1"}
+	c.YAML(http.StatusOK, m)
+}
+
+func deleteItem(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+func downloadFile(c *gin.Context) {
+	c.File("./files/" + c.Param("filepath"))
+}
+
+func uploadFile(c *gin.Context) {
+	if _, err := c.FormFile("file"); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	label := c.PostForm("label")
+	_ = label
+	c.Status(http.StatusAccepted)
+}
@@ -0,0 +1,20 @@
+package ginapp
+
+type Item struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CreateItem struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type ItemFilter struct {
+	Tags   []string `form:"tag"`
+	Search string   `form:"q"`
+}
+
+type Manifest struct {
+	Version string   `json:"version" yaml:"version"`
+	Items   []string `json:"items" yaml:"items"`
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/ginsecurity
+
+go 1.22
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"example.com/docoo/ginsecurity/orders"
+	"example.com/docoo/ginsecurity/users"
+)
+
+func main() {
+	r := gin.New()
+	r.GET("/health", health)
+
+	// The public group shares the /api path with the secured one.
+	public := r.Group("/api")
+	public.GET("/status", status)
+	orders.Register(public)
+
+	api := r.Group("/api", requireAuth())
+	users.Register(api)
+
+	admin := r.Group("/admin")
+	admin.GET("/ping", ping)
+	admin.Use(requireAuth())
+	admin.GET("/stats", stats)
+
+	r.Group("/v2", requireAuth()).GET("/reports", reports)
+
+	r.Run()
+}
+
+func requireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) { c.Next() }
+}
+
+func health(c *gin.Context)  { c.String(http.StatusOK, "ok") }
+func status(c *gin.Context)  { c.String(http.StatusOK, "up") }
+func ping(c *gin.Context)    { c.String(http.StatusOK, "pong") }
+func stats(c *gin.Context)   { c.JSON(http.StatusOK, gin.H{"users": 1}) }
+func reports(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"reports": 0}) }
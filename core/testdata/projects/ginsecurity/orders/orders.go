@@ -0,0 +1,21 @@
+package orders
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Order struct {
+	ID    string  `json:"id"`
+	Total float64 `json:"total"`
+}
+
+func Register(rg *gin.RouterGroup) {
+	rg.GET("/orders", listOrders)
+}
+
+func listOrders(c *gin.Context) {
+	var orders []Order
+	c.JSON(http.StatusOK, orders)
+}
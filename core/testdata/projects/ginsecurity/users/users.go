@@ -0,0 +1,21 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func Register(rg *gin.RouterGroup) {
+	rg.GET("/users", listUsers)
+}
+
+func listUsers(c *gin.Context) {
+	var users []User
+	c.JSON(http.StatusOK, users)
+}
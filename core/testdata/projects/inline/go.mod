@@ -0,0 +1,3 @@
+module example.com/docoo/inline
+
+go 1.22
@@ -0,0 +1,34 @@
+package inline
+
+type Note struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+func Register(app *App) {
+	app.Get("/health", func(c *Ctx) error {
+		return c.JSON(map[string]interface{}{
+			"status": "ok",
+		})
+	})
+
+	notes := app.Group("/notes")
+
+	// @Summary Create a note
+	// @Tags Notes
+	notes.Post("/", func(c *Ctx) error {
+		var note Note
+		if err := c.BodyParser(&note); err != nil {
+			return err
+		}
+		return c.Status(201).JSON(note)
+	})
+
+	// Fetch a single note by its identifier.
+	notes.Get("/:id",
+		func(c *Ctx) error {
+			var note Note
+			return c.JSON(note)
+		},
+	)
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/methodkeys
+
+go 1.22
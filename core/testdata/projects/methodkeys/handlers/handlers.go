@@ -0,0 +1,42 @@
+package handlers
+
+import "github.com/gofiber/fiber/v2"
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Order struct {
+	ID    string  `json:"id"`
+	Total float64 `json:"total"`
+}
+
+type Users struct{}
+
+func (u *Users) Register(r fiber.Router) {
+	r.Get("/", u.list)
+}
+
+func (u *Users) list(c *fiber.Ctx) error {
+	users := []User{}
+	return c.JSON(users)
+}
+
+type Orders struct{}
+
+func NewOrders() *Orders {
+	return &Orders{}
+}
+
+func (o *Orders) Register(r fiber.Router) {
+	r.Post("/", o.create)
+}
+
+func (o *Orders) create(c *fiber.Ctx) error {
+	var order Order
+	if err := c.BodyParser(&order); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(order)
+}
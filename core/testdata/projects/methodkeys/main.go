@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"example.com/docoo/methodkeys/handlers"
+)
+
+func main() {
+	app := fiber.New()
+	api := app.Group("/api")
+
+	u := &handlers.Users{}
+	o := handlers.NewOrders()
+	u.Register(api.Group("/users"))
+	o.Register(api.Group("/orders"))
+
+	var s Status
+	s.Register(app)
+
+	app.Listen(":8080")
+}
+
+type Status struct{}
+
+func (Status) Register(r fiber.Router) {
+	r.Get("/status", status)
+}
+
+func status(c *fiber.Ctx) error {
+	return c.SendString("ok")
+}
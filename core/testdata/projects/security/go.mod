@@ -0,0 +1,3 @@
+module example.com/docoo/security
+
+go 1.22
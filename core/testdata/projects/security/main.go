@@ -0,0 +1,49 @@
+package main
+
+import (
+	"example.com/docoo/security/users"
+	jwtware "github.com/gofiber/contrib/jwt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/keyauth"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+)
+
+func main() {
+	app := fiber.New()
+	app.Use(logger.New())
+	app.Get("/health", health)
+
+	api := app.Group("/api", jwtware.New(jwtware.Config{}))
+	users.Register(api)
+
+	admin := app.Group("/admin")
+	admin.Get("/login", login)
+	admin.Use(requireAuth("admin"))
+	admin.Get("/stats", stats)
+
+	app.Get("/keys", keyauth.New(keyauth.Config{}), listKeys)
+
+	_ = app.Listen(":3000")
+}
+
+func requireAuth(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Next()
+	}
+}
+
+func health(c *fiber.Ctx) error {
+	return c.SendString("ok")
+}
+
+func login(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func stats(c *fiber.Ctx) error {
+	return c.SendString("stats")
+}
+
+func listKeys(c *fiber.Ctx) error {
+	return c.SendString("keys")
+}
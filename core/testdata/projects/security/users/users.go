@@ -0,0 +1,17 @@
+package users
+
+import "github.com/gofiber/fiber/v2"
+
+func Register(r fiber.Router) {
+	r.Get("/users", listUsers)
+	r.Get("/users/public", publicUsers)
+}
+
+func listUsers(c *fiber.Ctx) error {
+	return c.SendString("users")
+}
+
+// @NoAuth
+func publicUsers(c *fiber.Ctx) error {
+	return c.SendString("public")
+}
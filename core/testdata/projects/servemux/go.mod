@@ -0,0 +1,3 @@
+module example.com/docoo/servemux
+
+go 1.22
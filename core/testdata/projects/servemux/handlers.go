@@ -0,0 +1,59 @@
+package servemux
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type UserHandler struct{}
+
+func Register(mux *http.ServeMux, h *UserHandler) {
+	mux.HandleFunc("GET /users/{id}", h.getUser)
+	mux.HandleFunc("POST /users", h.createUser)
+	mux.Handle("DELETE api.example.com/users/{id}", http.HandlerFunc(h.deleteUser))
+	mux.HandleFunc("GET /files/{path...}", serveFile)
+	mux.HandleFunc("/{$}", index)
+
+	http.HandleFunc("GET /search", search)
+}
+
+func (h *UserHandler) getUser(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.NotFound(w, r)
+		return
+	}
+	user := User{ID: id}
+	json.NewEncoder(w).Encode(user)
+}
+
+func (h *UserHandler) createUser(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	created := User{ID: "1", Name: req.Name}
+	json.NewEncoder(w).Encode(created)
+}
+
+func (h *UserHandler) deleteUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func serveFile(w http.ResponseWriter, r *http.Request) {
+	http.ServeFile(w, r, r.PathValue("path"))
+}
+
+func index(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("ok"))
+}
+
+func search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	term := q.Get("q")
+	limit := r.URL.Query().Get("limit")
+	result := SearchResult{Term: term, Limit: limit}
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,15 @@
+package servemux
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type CreateUserRequest struct {
+	Name string `json:"name"`
+}
+
+type SearchResult struct {
+	Term  string `json:"term"`
+	Limit string `json:"limit"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type CreateOrder struct {
+	SKU    string `json:"sku"`
+	DryRun bool   `json:"dry_run"`
+}
+
+type Order struct {
+	ID  string `json:"id"`
+	SKU string `json:"sku"`
+}
+
+type Quote struct {
+	SKU   string  `json:"sku"`
+	Total float64 `json:"total"`
+}
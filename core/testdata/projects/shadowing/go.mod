@@ -0,0 +1,3 @@
+module example.com/docoo/shadowing
+
+go 1.22
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"example.com/docoo/shadowing/dto"
+)
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /orders", createOrder)
+}
+
+func createOrder(w http.ResponseWriter, r *http.Request) {
+	var in dto.CreateOrder
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	out := placeOrder(in)
+	if in.DryRun {
+		out := quote(in)
+		json.NewEncoder(w).Encode(out)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(out)
+}
+
+func placeOrder(in dto.CreateOrder) dto.Order {
+	return dto.Order{SKU: in.SKU}
+}
+
+func quote(in dto.CreateOrder) dto.Quote {
+	return dto.Quote{SKU: in.SKU}
+}
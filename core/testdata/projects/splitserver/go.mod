@@ -0,0 +1,3 @@
+module example.com/docoo/splitserver
+
+go 1.22
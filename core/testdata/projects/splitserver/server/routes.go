@@ -0,0 +1,31 @@
+package server
+
+import "github.com/gofiber/fiber/v2"
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (s *Server) routes() {
+	s.app.Get("/users", listUsers)
+	s.app.Post("/users", createUser)
+	admin.Get("/health", health)
+}
+
+func listUsers(c *fiber.Ctx) error {
+	var users []User
+	return c.JSON(users)
+}
+
+func createUser(c *fiber.Ctx) error {
+	var in User
+	if err := c.BodyParser(&in); err != nil {
+		return fiber.ErrBadRequest
+	}
+	return c.Status(fiber.StatusCreated).JSON(in)
+}
+
+func health(c *fiber.Ctx) error {
+	return c.SendString("ok")
+}
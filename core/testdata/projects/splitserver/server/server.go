@@ -0,0 +1,15 @@
+package server
+
+import "github.com/gofiber/fiber/v2"
+
+var admin = fiber.New()
+
+type Server struct {
+	app *fiber.App
+}
+
+func New() *Server {
+	s := &Server{app: fiber.New()}
+	s.routes()
+	return s
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/swaggo
+
+go 1.22
@@ -0,0 +1,73 @@
+package main
+
+import (
+	_ "example.com/docoo/swaggo/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func main() {
+	app := fiber.New()
+	api := app.Group("/api/v1")
+	api.Get("/users", listUsers)
+	api.Get("/users/:id", getUser)
+	api.Delete("/users/:id", deleteUser)
+	api.Get("/legacy", legacy)
+	app.Get("/orders/:id", getOrder)
+
+	_ = app.Listen(":3000")
+}
+
+// listUsers godoc
+// @Summary List users
+// @Param status query string false "Filter by status" Enums(active, disabled) default(active)
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20) example(10)
+// @Param tags query []string false "Tags" Enums(admin, staff)
+// @Success 200 {object} response.Envelope{data=[]User,meta=response.Meta} "Users page"
+// @Header 200 {string} X-Total-Count "Total number of users"
+// @Failure 400 {object} response.Error "Bad request"
+// @Security ApiKeyAuth
+// @Router /users [get]
+func listUsers(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{})
+}
+
+// getUser godoc
+// @Summary Get a user
+// @ID getUserByID
+// @Success 200 {object} response.Envelope{data=User}
+// @Security OAuth2[read, write] || ApiKeyAuth
+// @Router /users/{id} [get]
+func getUser(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{})
+}
+
+// deleteUser godoc
+// @Summary Delete a user
+// @Success 204 "Deleted"
+// @Header all {string} X-Request-Id "Request id"
+// @Router /users/{id} [delete]
+func deleteUser(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// legacy godoc
+// @Summary Legacy listing
+// @Deprecated
+// @Success 200 {array} User
+// @Router /legacy [get]
+func legacy(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{})
+}
+
+// getOrder godoc
+// @Summary Get an order
+// @Success 200 {string} string "Order"
+// @Router /v2/orders/{id} [get]
+func getOrder(c *fiber.Ctx) error {
+	return c.SendString("order")
+}
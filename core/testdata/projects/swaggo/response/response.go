@@ -0,0 +1,14 @@
+package response
+
+type Envelope struct {
+	Data interface{} `json:"data"`
+	Meta *Meta       `json:"meta,omitempty"`
+}
+
+type Meta struct {
+	Total int `json:"total"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"example.com/docoo/swaginfo/orders"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @title           Orders Service
+// @version         2.1.0
+// @description     Order management for the storefront.
+// @termsOfService  https://example.com/terms
+
+// @contact.name   API Support
+// @contact.url    https://example.com/support
+// @contact.email  support@example.com
+
+// @license.name  Apache 2.0
+// @license.url   https://www.apache.org/licenses/LICENSE-2.0.html
+
+// @host      api.example.com
+// @BasePath  /api/v1
+// @schemes   https
+
+// @securityDefinitions.basic  BasicAuth
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Key issued per client.
+
+// @securityDefinitions.oauth2.accessCode  OAuth2
+// @authorizationUrl                       https://auth.example.com/authorize
+// @tokenUrl                               https://auth.example.com/token
+// @scope.read                             Read orders
+// @scope.writeOrders                      Create orders
+func main() {
+	app := fiber.New()
+	v1 := app.Group("/api/v1")
+	orders.Register(v1)
+	_ = app.Listen(":8080")
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/swaginfo
+
+go 1.22
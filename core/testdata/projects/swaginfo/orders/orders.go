@@ -0,0 +1,30 @@
+package orders
+
+import "github.com/gofiber/fiber/v2"
+
+type Order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func Register(router fiber.Router) {
+	router.Get("/orders", list)
+	router.Post("/orders", create)
+}
+
+// list returns every order.
+// @Security ApiKeyAuth
+func list(c *fiber.Ctx) error {
+	var orders []Order
+	return c.JSON(orders)
+}
+
+// create stores a new order.
+// @Security OAuth2[writeOrders]
+func create(c *fiber.Ctx) error {
+	var order Order
+	if err := c.BodyParser(&order); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(order)
+}
@@ -0,0 +1,3 @@
+module example.com/docoo/swaginfomain
+
+go 1.22
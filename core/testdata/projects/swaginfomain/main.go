@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// @title Users API
+// @version 1.2.0
+// @description Manages the users of the directory.
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-Key
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", listUsers)
+	http.ListenAndServe(":8080", mux)
+}
+
+// listUsers lists the users.
+//
+// @Summary List users
+// @Description Lists every user.
+// @Tags users
+// @Success 200 {array} User
+// @Security ApiKeyAuth
+// @Router /users [get]
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	var users []User
+	json.NewEncoder(w).Encode(users)
+}
@@ -0,0 +1,6 @@
+package dto
+
+type Item struct {
+	InvoiceID string  `json:"invoice_id"`
+	Amount    float64 `json:"amount"`
+}
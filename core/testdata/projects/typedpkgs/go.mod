@@ -0,0 +1,3 @@
+module example.com/docoo/typedpkgs
+
+go 1.22
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	billingdto "example.com/docoo/typedpkgs/billing/dto"
+	userdto "example.com/docoo/typedpkgs/users/dto"
+)
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /invoices", listInvoices)
+	mux.HandleFunc("POST /users", createUser)
+}
+
+func listInvoices(w http.ResponseWriter, r *http.Request) {
+	invoices := loadInvoices()
+	json.NewEncoder(w).Encode(invoices)
+}
+
+func createUser(w http.ResponseWriter, r *http.Request) {
+	var in userdto.Item
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	created := store(in)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func loadInvoices() []billingdto.Item {
+	return nil
+}
+
+func store(item userdto.Item) userdto.Item {
+	return item
+}
@@ -0,0 +1,10 @@
+package dto
+
+type Item struct {
+	Email   string   `json:"email"`
+	Address *Address `json:"address,omitempty"`
+}
+
+type Address struct {
+	City string `json:"city"`
+}
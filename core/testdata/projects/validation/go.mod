@@ -0,0 +1,3 @@
+module example.com/docoo/validation
+
+go 1.22
@@ -0,0 +1,40 @@
+package validation
+
+import "github.com/gofiber/fiber/v2"
+
+type Address struct {
+	Country string `json:"country" validate:"required,len=2,alpha"`
+	Zip     string `json:"zip,omitempty" validate:"omitempty,numeric,min=4,max=10"`
+}
+
+type CreateUser struct {
+	ID       string             `json:"id" validate:"required,uuid4"`
+	Email    string             `json:"email" validate:"required,email"`
+	Name     string             `json:"name" validate:"min=1,max=100"`
+	Nickname *string            `json:"nickname" validate:"required"`
+	Website  string             `json:"website" validate:"omitempty,url"`
+	Age      int                `json:"age" validate:"gte=18,lt=130"`
+	Score    float64            `json:"score" validate:"gt=0,lte=1"`
+	Role     string             `json:"role" validate:"oneof=admin editor 'read only'"`
+	Level    int                `json:"level" validate:"oneof=1 2 3"`
+	Tags     []string           `json:"tags" validate:"max=5,unique,dive,min=2,startswith=t_"`
+	Matrix   [][]int            `json:"matrix" validate:"dive,len=3,dive,min=0"`
+	Labels   map[string]string  `json:"labels" validate:"max=10,dive,keys,alpha,endkeys,max=64"`
+	Birthday string             `json:"birthday" validate:"datetime=2006-01-02"`
+	Color    string             `json:"color" validate:"hexcolor|rgb"`
+	Address  Address            `json:"address" validate:"required"`
+	Previous []Address          `json:"previous" validate:"min=1,dive,required"`
+	Extra    map[string]Address `json:"extra,omitempty"`
+}
+
+func Register(app *fiber.App) {
+	app.Post("/users", createUser)
+}
+
+func createUser(c *fiber.Ctx) error {
+	var req CreateUser
+	if err := c.BodyParser(&req); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(req)
+}
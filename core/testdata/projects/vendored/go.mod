@@ -0,0 +1,5 @@
+module example.com/docoo/vendored
+
+go 1.22
+
+require github.com/ourorg/apitypes v1.2.0
@@ -0,0 +1,25 @@
+package vendored
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ourorg/apitypes"
+)
+
+func Register(app *fiber.App) {
+	app.Get("/users/:id", getUser)
+	app.Post("/users", createUser)
+}
+
+func getUser(c *fiber.Ctx) error {
+	var user apitypes.User
+	return c.JSON(user)
+}
+
+func createUser(c *fiber.Ctx) error {
+	var in apitypes.CreateUserRequest
+	if err := c.BodyParser(&in); err != nil {
+		return err
+	}
+	user := apitypes.User{Email: in.Email}
+	return c.Status(fiber.StatusCreated).JSON(user)
+}
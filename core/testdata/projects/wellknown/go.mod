@@ -0,0 +1,3 @@
+module example.com/docoo/wellknown
+
+go 1.22
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"time"
+
+	"example.com/docoo/wellknown/money"
+)
+
+type Job struct {
+	Timeout  time.Duration         `json:"timeout"`
+	Payload  json.RawMessage       `json:"payload"`
+	Note     sql.NullString        `json:"note"`
+	Attempts sql.NullInt64         `json:"attempts"`
+	Finished sql.NullTime          `json:"finished"`
+	Worker   netip.Addr            `json:"worker"`
+	Callback *url.URL              `json:"callback,omitempty"`
+	Cost     money.Amount          `json:"cost"`
+	Delays   []time.Duration       `json:"delays"`
+	Peers    map[string]netip.Addr `json:"peers"`
+}
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /jobs/{id}", getJob)
+	mux.HandleFunc("POST /jobs", createJob)
+}
+
+func getJob(w http.ResponseWriter, r *http.Request) {
+	var job Job
+	json.NewEncoder(w).Encode(job)
+}
+
+func createJob(w http.ResponseWriter, r *http.Request) {
+	var job Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(job)
+}
@@ -0,0 +1,7 @@
+package money
+
+// Amount is a decimal amount of money, encoded as a string.
+type Amount struct {
+	Units int64
+	Nanos int32
+}
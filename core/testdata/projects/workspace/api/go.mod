@@ -0,0 +1,5 @@
+module example.com/ws/api
+
+go 1.22
+
+require example.com/ws/users v0.0.0
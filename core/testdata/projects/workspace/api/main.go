@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"example.com/ws/users"
+)
+
+func main() {
+	app := fiber.New()
+
+	api := app.Group("/api")
+	api.Get("/users/:id", users.GetUser)
+	api.Post("/users", users.CreateUser)
+
+	_ = app.Listen(":3000")
+}
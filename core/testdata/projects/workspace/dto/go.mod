@@ -0,0 +1,3 @@
+module example.com/ws/dto
+
+go 1.22
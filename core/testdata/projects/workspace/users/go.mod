@@ -0,0 +1,7 @@
+module example.com/ws/users
+
+go 1.22
+
+require example.com/ws/dto v0.0.0
+
+replace example.com/ws/dto => ../dto
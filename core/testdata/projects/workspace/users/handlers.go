@@ -0,0 +1,23 @@
+package users
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"example.com/ws/dto"
+)
+
+// GetUser returns a single user.
+func GetUser(c *fiber.Ctx) error {
+	var user dto.User
+	return c.JSON(user)
+}
+
+// CreateUser stores a new user.
+func CreateUser(c *fiber.Ctx) error {
+	var in dto.CreateUserRequest
+	if err := c.BodyParser(&in); err != nil {
+		return err
+	}
+	user := dto.User{Name: in.Name}
+	return c.Status(fiber.StatusCreated).JSON(user)
+}
@@ -0,0 +1,270 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	stdpath "path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// typedIndex keeps the go/types view of a loaded module. The syntax trees are the
+// ones type-checked by go/packages, so handler analysis must use them for the
+// expression lookups to hit.
+type typedIndex struct {
+	fset    *token.FileSet
+	files   map[string]*ast.File // cleaned absolute file path -> syntax
+	types   map[ast.Expr]types.Type
+	objects map[*ast.Ident]types.Object // Defs and Uses
+	local   map[string]struct{}         // import paths of the module's own packages
+}
+
+// LoadPackages type-checks the module rooted at root with go/packages and makes
+// the registry resolve handler variables, call results and struct fields through
+// go/types. Imported packages of other go.work modules and of local replace
+// targets are indexed too. Types declared in the module are then keyed by import path, so two
+// packages sharing a name no longer collide. When loading fails the registry is
+// left untouched and keeps using the AST heuristics. Expressions that do not
+// type-check (for example because a dependency is missing) fall back per
+// expression.
+func (r *TypeRegistry) LoadPackages(root string) error {
+	if r == nil {
+		return errors.New("core: nil type registry")
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+		Dir:  root,
+		Fset: token.NewFileSet(),
+		// Never touch the network or rewrite the project's go.mod/go.sum.
+		Env: append(os.Environ(), "GOFLAGS=-mod=readonly", "GOPROXY=off"),
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return fmt.Errorf("core: load packages in %s: %w", root, err)
+	}
+
+	idx := &typedIndex{
+		fset:    cfg.Fset,
+		files:   make(map[string]*ast.File),
+		types:   make(map[ast.Expr]types.Type),
+		objects: make(map[*ast.Ident]types.Object),
+		local:   make(map[string]struct{}),
+	}
+	roots := make(map[*packages.Package]struct{}, len(pkgs))
+	for _, pkg := range pkgs {
+		roots[pkg] = struct{}{}
+	}
+	var loaded []*packages.Package
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if _, ok := roots[pkg]; ok || isWorkspaceModule(pkg.Module) {
+			loaded = append(loaded, pkg)
+		}
+	})
+	for _, pkg := range loaded {
+		if pkg.Types == nil || pkg.TypesInfo == nil || len(pkg.Syntax) != len(pkg.CompiledGoFiles) {
+			continue
+		}
+		idx.local[pkg.PkgPath] = struct{}{}
+		for i, file := range pkg.Syntax {
+			path := filepath.Clean(pkg.CompiledGoFiles[i])
+			idx.files[path] = file
+			r.addEnums(path, file)
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						r.addWithPath(pkg.Name, pkg.PkgPath, path, ts)
+						r.recordTypeDoc(gen, ts)
+					}
+				}
+			}
+		}
+		for expr, tv := range pkg.TypesInfo.Types {
+			if tv.Type != nil {
+				idx.types[expr] = tv.Type
+			}
+		}
+		for ident, obj := range pkg.TypesInfo.Defs {
+			if obj != nil {
+				idx.objects[ident] = obj
+			}
+		}
+		for ident, obj := range pkg.TypesInfo.Uses {
+			idx.objects[ident] = obj
+		}
+	}
+	if len(idx.files) == 0 {
+		return fmt.Errorf("core: load packages in %s: no type-checked packages", root)
+	}
+	r.typed = idx
+	return nil
+}
+
+// isWorkspaceModule reports whether mod is edited locally: a module of the
+// go.work workspace or the target of a replace directive naming a directory.
+func isWorkspaceModule(mod *packages.Module) bool {
+	if mod == nil {
+		return false
+	}
+	return mod.Main || (mod.Replace != nil && mod.Replace.Version == "")
+}
+
+// TypeChecked reports whether LoadPackages succeeded for this registry.
+func (r *TypeRegistry) TypeChecked() bool {
+	return r != nil && r.typed != nil
+}
+
+// syntaxFor returns the type-checked syntax tree for path and the file set it
+// was parsed with, if any.
+func (r *TypeRegistry) syntaxFor(path string) (*ast.File, *token.FileSet) {
+	if r == nil || r.typed == nil {
+		return nil, nil
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	file := r.typed.files[filepath.Clean(path)]
+	if file == nil {
+		return nil, nil
+	}
+	return file, r.typed.fset
+}
+
+// typedExprType returns the type of expr in registry notation: pointers are
+// dereferenced, module types are qualified by import path and other packages
+// by name (time.Time, fiber.Map). It returns "" when the type is unknown.
+func (r *TypeRegistry) typedExprType(expr ast.Expr) string {
+	if r == nil || r.typed == nil || expr == nil {
+		return ""
+	}
+	if t, ok := r.typed.types[expr]; ok {
+		return r.typed.typeString(t)
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		if v, ok := r.typed.objects[ident].(*types.Var); ok {
+			return r.typed.typeString(v.Type())
+		}
+	}
+	return ""
+}
+
+// typedVarTypes maps each identifier in body that denotes a local variable or
+// parameter to its type. Identifiers rather than names are keys, so a variable
+// shadowed in an inner scope keeps its own type.
+func (r *TypeRegistry) typedVarTypes(body ast.Node) map[*ast.Ident]string {
+	if r == nil || r.typed == nil || body == nil {
+		return nil
+	}
+	result := make(map[*ast.Ident]string)
+	ast.Inspect(body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		v, ok := r.typed.objects[ident].(*types.Var)
+		if !ok || v.IsField() {
+			return true
+		}
+		if typ := r.typed.typeString(v.Type()); typ != "" {
+			result[ident] = typ
+		}
+		return true
+	})
+	return result
+}
+
+func (idx *typedIndex) typeString(t types.Type) string {
+	if t == nil {
+		return ""
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	switch u := t.(type) {
+	case *types.Tuple, *types.Signature, *types.Chan:
+		return ""
+	case *types.Basic:
+		if u.Kind() == types.Invalid {
+			return ""
+		}
+		t = types.Default(u)
+	}
+	s := types.TypeString(t, func(p *types.Package) string {
+		if _, ok := idx.local[p.Path()]; ok {
+			return p.Path()
+		}
+		return p.Name()
+	})
+	if strings.Contains(s, "invalid type") {
+		return ""
+	}
+	return s
+}
+
+// splitPathQualified splits "example.com/app/dto.User" into its import path and
+// type name. Names qualified by a bare package name are not path-qualified.
+func splitPathQualified(typeName string) (string, string, bool) {
+	idx := strings.LastIndex(typeName, ".")
+	if idx <= 0 || !strings.Contains(typeName[:idx], "/") {
+		return "", "", false
+	}
+	return typeName[:idx], typeName[idx+1:], true
+}
+
+// componentName derives the schema component name for a type. Path-qualified
+// names use the package name, widened with parent path segments while another
+// loaded package of the same name declares the same type.
+func (r *TypeRegistry) componentName(typeName string) string {
+	pkgPath, name, ok := splitPathQualified(strings.TrimPrefix(strings.TrimSpace(typeName), "*"))
+	if !ok {
+		return buildComponentName(typeName)
+	}
+	pkgName := stdpath.Base(pkgPath)
+	if r != nil {
+		if info := r.byPath[pkgPath][name]; info != nil {
+			pkgName = info.Package
+		}
+	}
+	segments := strings.Split(pkgPath, "/")
+	parents := segments[:len(segments)-1]
+	for n := 0; n <= len(parents); n++ {
+		prefix := append(append([]string(nil), parents[len(parents)-n:]...), pkgName)
+		if !r.componentPrefixTaken(prefix, pkgPath, name) {
+			return buildComponentName(strings.Join(prefix, "_") + "." + name)
+		}
+	}
+	return buildComponentName(strings.ReplaceAll(pkgPath, "/", "_") + "." + name)
+}
+
+// componentPrefixTaken reports whether a package other than pkgPath would be
+// named by prefix and declares name.
+func (r *TypeRegistry) componentPrefixTaken(prefix []string, pkgPath, name string) bool {
+	if r == nil {
+		return false
+	}
+	for otherPath, specs := range r.byPath {
+		info := specs[name]
+		if otherPath == pkgPath || info == nil {
+			continue
+		}
+		segments := strings.Split(otherPath, "/")
+		if len(segments) < len(prefix) {
+			continue
+		}
+		other := append(append([]string(nil), segments[len(segments)-len(prefix):len(segments)-1]...), info.Package)
+		if strings.Join(other, "/") == strings.Join(prefix, "/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package core
+
+import "strings"
+
+// wellKnownTypes maps package name qualified types to their schemas, as
+// written in source or reported by the type checker for non-local packages.
+// These types are not declared in the scanned project, so they are described
+// by the JSON they marshal to: uuid.UUID is a string with format uuid and
+// json.RawMessage any JSON value.
+var wellKnownTypes = map[string]map[string]interface{}{
+	"time.Duration":       {"type": "integer", "format": "int64"},
+	"json.RawMessage":     {},
+	"json.Number":         {"type": "number"},
+	"uuid.UUID":           {"type": "string", "format": "uuid"},
+	"uuid.NullUUID":       {"type": "string", "format": "uuid", "nullable": true},
+	"decimal.Decimal":     {"type": "string", "format": "decimal"},
+	"decimal.NullDecimal": {"type": "string", "format": "decimal", "nullable": true},
+	"netip.Addr":          {"type": "string", "format": "ip"},
+	"netip.AddrPort":      {"type": "string"},
+	"netip.Prefix":        {"type": "string", "format": "cidr"},
+	"net.IP":              {"type": "string", "format": "ip"},
+	"big.Int":             {"type": "integer"},
+	"big.Float":           {"type": "string", "format": "decimal"},
+
+	// The sql.Null types and url.URL have no JSON methods, so encoding/json
+	// writes their exported fields.
+	"sql.NullString":  sqlNullSchema("String", map[string]interface{}{"type": "string"}),
+	"sql.NullInt64":   sqlNullSchema("Int64", map[string]interface{}{"type": "integer", "format": "int64"}),
+	"sql.NullInt32":   sqlNullSchema("Int32", map[string]interface{}{"type": "integer", "format": "int32"}),
+	"sql.NullInt16":   sqlNullSchema("Int16", map[string]interface{}{"type": "integer"}),
+	"sql.NullByte":    sqlNullSchema("Byte", map[string]interface{}{"type": "integer"}),
+	"sql.NullFloat64": sqlNullSchema("Float64", map[string]interface{}{"type": "number", "format": "double"}),
+	"sql.NullBool":    sqlNullSchema("Bool", map[string]interface{}{"type": "boolean"}),
+	"sql.NullTime":    sqlNullSchema("Time", map[string]interface{}{"type": "string", "format": "date-time"}),
+	"url.URL": {
+		"type": "object",
+		"properties": map[string]interface{}{
+			"Scheme":      map[string]interface{}{"type": "string"},
+			"Opaque":      map[string]interface{}{"type": "string"},
+			"User":        map[string]interface{}{"type": "object", "nullable": true},
+			"Host":        map[string]interface{}{"type": "string"},
+			"Path":        map[string]interface{}{"type": "string"},
+			"RawPath":     map[string]interface{}{"type": "string"},
+			"OmitHost":    map[string]interface{}{"type": "boolean"},
+			"ForceQuery":  map[string]interface{}{"type": "boolean"},
+			"RawQuery":    map[string]interface{}{"type": "string"},
+			"Fragment":    map[string]interface{}{"type": "string"},
+			"RawFragment": map[string]interface{}{"type": "string"},
+		},
+	},
+}
+
+func sqlNullSchema(field string, value map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			field:   value,
+			"Valid": map[string]interface{}{"type": "boolean"},
+		},
+		"required": []string{field, "Valid"},
+	}
+}
+
+// typeMappingKeys indexes ProjectConfig.TypeMappings, which add to or override
+// wellKnownTypes, by the names a type may be written as: import path qualified
+// keys ("example.com/app/money.Amount") are also reachable by package name
+// ("money.Amount").
+// A key given in both forms keeps the package name entry.
+func typeMappingKeys(mappings map[string]map[string]interface{}) map[string]map[string]interface{} {
+	if len(mappings) == 0 {
+		return nil
+	}
+	index := make(map[string]map[string]interface{}, len(mappings))
+	for key, schema := range mappings {
+		key = strings.TrimSpace(key)
+		index[key] = schema
+	}
+	for key, schema := range mappings {
+		if short := shortTypeName(strings.TrimSpace(key)); short != "" {
+			if _, exists := index[short]; !exists {
+				index[short] = schema
+			}
+		}
+	}
+	return index
+}
+
+// shortTypeName turns "example.com/app/money.Amount" into "money.Amount",
+// naming the package as an import of it does. It returns "" for other names.
+func shortTypeName(typeName string) string {
+	pkgPath, name, ok := splitPathQualified(typeName)
+	if !ok {
+		return ""
+	}
+	return defaultImportAlias(pkgPath) + "." + name
+}
+
+// mappedTypeSchema returns a copy of the schema configured or built in for
+// typeName declared in pkg, or nil when the type is not mapped.
+func mappedTypeSchema(typeName, pkg string, b *componentBuilder) map[string]interface{} {
+	names := []string{typeName}
+	if !strings.Contains(typeName, ".") && pkg != "" {
+		names = append(names, pkg+"."+typeName)
+	}
+	if short := shortTypeName(typeName); short != "" {
+		names = append(names, short)
+	}
+	if b != nil {
+		for _, name := range names {
+			if schema, ok := b.typeMappings[name]; ok {
+				return cloneSchema(schema)
+			}
+		}
+	}
+	for _, name := range names {
+		if schema, ok := wellKnownTypes[name]; ok {
+			return cloneSchema(schema)
+		}
+	}
+	return nil
+}
+
+// cloneSchema deep-copies schema, since callers add validation and
+// descriptions to the schemas they get.
+func cloneSchema(schema map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		out[key] = cloneSchemaValue(value)
+	}
+	return out
+}
+
+func cloneSchemaValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return cloneSchema(v)
+	case Schema:
+		return cloneSchema(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = cloneSchemaValue(item)
+		}
+		return out
+	case []string:
+		return append([]string(nil), v...)
+	}
+	return value
+}
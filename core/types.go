@@ -5,8 +5,10 @@ import (
 	"go/parser"
 	"go/token"
 	"io/fs"
+	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -14,8 +16,12 @@ import (
 // TypeRegistry tracks type specifications discovered while scanning source files.
 type TypeRegistry struct {
 	packages         map[string]map[string]*TypeSpecInfo
+	byPath           map[string]map[string]*TypeSpecInfo // import path -> types, filled in type-checked mode
 	functions        map[string][]FuncSignature
+	enums            map[string][]enumValue // enumKey -> typed constants in declaration order
+	typeDocs         map[*ast.TypeSpec]*ast.CommentGroup
 	indexedWorkspace bool
+	typed            *typedIndex
 }
 
 // FuncSignature captures a function's return types within a package.
@@ -27,6 +33,7 @@ type FuncSignature struct {
 // TypeSpecInfo stores metadata about a type declaration.
 type TypeSpecInfo struct {
 	Package string
+	PkgPath string // import path; empty unless loaded in type-checked mode
 	Name    string
 	File    string
 	Spec    *ast.TypeSpec
@@ -36,7 +43,9 @@ type TypeSpecInfo struct {
 func NewTypeRegistry() *TypeRegistry {
 	return &TypeRegistry{
 		packages:  make(map[string]map[string]*TypeSpecInfo),
+		byPath:    make(map[string]map[string]*TypeSpecInfo),
 		functions: make(map[string][]FuncSignature),
+		enums:     make(map[string][]enumValue),
 	}
 }
 
@@ -61,6 +70,21 @@ func (r *TypeRegistry) Add(pkg, file string, spec *ast.TypeSpec) {
 	pkgMap[name] = &TypeSpecInfo{Package: pkg, Name: name, File: file, Spec: spec}
 }
 
+// addWithPath records a type-checked declaration under both its package name and
+// its import path.
+func (r *TypeRegistry) addWithPath(pkg, pkgPath, file string, spec *ast.TypeSpec) {
+	if r == nil || spec == nil || spec.Name == nil || spec.Name.Name == "" {
+		return
+	}
+	r.Add(pkg, file, spec)
+	pathMap := r.byPath[pkgPath]
+	if pathMap == nil {
+		pathMap = make(map[string]*TypeSpecInfo)
+		r.byPath[pkgPath] = pathMap
+	}
+	pathMap[spec.Name.Name] = &TypeSpecInfo{Package: pkg, PkgPath: pkgPath, Name: spec.Name.Name, File: file, Spec: spec}
+}
+
 // AddFunction registers a function or method signature.
 func (r *TypeRegistry) AddFunction(pkg, name string, results []string) {
 	if r == nil || name == "" || len(results) == 0 {
@@ -109,6 +133,12 @@ func (r *TypeRegistry) Resolve(typeName, defaultPkg string) (*TypeSpecInfo, stri
 	if r == nil || typeName == "" {
 		return nil, ""
 	}
+	if pkgPath, name, ok := splitPathQualified(typeName); ok {
+		if info := r.byPath[pkgPath][name]; info != nil {
+			return info, typeName
+		}
+		return nil, typeName
+	}
 	pkg := defaultPkg
 	name := typeName
 	if idx := strings.Index(typeName, "."); idx > 0 {
@@ -177,7 +207,25 @@ func (r *TypeRegistry) IndexWorkspace(root string) error {
 	if r == nil || root == "" || r.indexedWorkspace {
 		return nil
 	}
-	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+	// Sibling modules of a go.work and local replace targets hold handlers
+	// and types too.
+	modules := loadModuleSet(root)
+	imports := make(map[string]struct{})
+	dirs := append([]string{root}, modules.dirsOutside(root)...)
+	for _, dir := range dirs {
+		if err := r.indexDir(dir, imports); err != nil {
+			return err
+		}
+	}
+	r.indexExternalImports(modules, imports)
+	r.indexedWorkspace = true
+	return nil
+}
+
+// indexDir records the types and function signatures of the Go files under dir
+// and collects the packages they import.
+func (r *TypeRegistry) indexDir(root string, imports map[string]struct{}) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -191,41 +239,93 @@ func (r *TypeRegistry) IndexWorkspace(root string) error {
 		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
 			return nil
 		}
-		fset := token.NewFileSet()
-		node, parseErr := parser.ParseFile(fset, path, nil, parser.ParseComments)
-		if parseErr != nil {
-			return nil
+		r.indexFile(path, imports, true)
+		return nil
+	})
+}
+
+// indexExternalImports records the types of the imported packages that live in
+// external modules (vendor/ or the module cache), following their imports
+// within the same module so that nested DTOs resolve too. Only imported
+// packages are read, never whole modules, and their functions are not indexed.
+func (r *TypeRegistry) indexExternalImports(modules *moduleSet, imports map[string]struct{}) {
+	queue := make([]string, 0, len(imports))
+	for importPath := range imports {
+		queue = append(queue, importPath)
+	}
+	sort.Strings(queue)
+	visited := make(map[string]struct{})
+	for len(queue) > 0 {
+		importPath := queue[0]
+		queue = queue[1:]
+		if _, done := visited[importPath]; done {
+			continue
 		}
-		pkgName := node.Name.Name
-		for _, decl := range node.Decls {
-			switch typed := decl.(type) {
-			case *ast.GenDecl:
-				if typed.Tok != token.TYPE {
-					continue
-				}
-				for _, spec := range typed.Specs {
-					if ts, ok := spec.(*ast.TypeSpec); ok {
-						r.Add(pkgName, path, ts)
-					}
-				}
-			case *ast.FuncDecl:
-				if typed.Type == nil || typed.Type.Results == nil {
-					continue
-				}
-				results := collectResultTypes(typed.Type.Results)
-				if len(results) == 0 {
-					continue
-				}
-				r.AddFunction(pkgName, typed.Name.Name, results)
+		visited[importPath] = struct{}{}
+		dir, mod, ok := modules.resolve(importPath)
+		if !ok || !mod.external {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		found := make(map[string]struct{})
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
 			}
+			r.indexFile(filepath.Join(dir, name), found, false)
 		}
-		return nil
-	})
+		var next []string
+		for importPath := range found {
+			if importPath == mod.path || strings.HasPrefix(importPath, mod.path+"/") {
+				next = append(next, importPath)
+			}
+		}
+		sort.Strings(next)
+		queue = append(queue, next...)
+	}
+}
+
+// indexFile records the types and typed constants declared in the file at path,
+// and its function signatures when withFuncs is set, adding its imports to
+// imports.
+func (r *TypeRegistry) indexFile(path string, imports map[string]struct{}, withFuncs bool) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
 	if err != nil {
-		return err
+		return
+	}
+	for _, importPath := range fileImportAliases(node) {
+		imports[importPath] = struct{}{}
+	}
+	pkgName := node.Name.Name
+	r.addEnums(path, node)
+	for _, decl := range node.Decls {
+		switch typed := decl.(type) {
+		case *ast.GenDecl:
+			if typed.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range typed.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					r.Add(pkgName, path, ts)
+					r.recordTypeDoc(typed, ts)
+				}
+			}
+		case *ast.FuncDecl:
+			if !withFuncs || typed.Type == nil || typed.Type.Results == nil {
+				continue
+			}
+			results := collectResultTypes(typed.Type.Results)
+			if len(results) == 0 {
+				continue
+			}
+			r.AddFunction(pkgName, typed.Name.Name, results)
+		}
 	}
-	r.indexedWorkspace = true
-	return nil
 }
 
 func collectResultTypes(list *ast.FieldList) []string {
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"go/ast"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validatorFormats maps validator's format rules to OpenAPI formats.
+var validatorFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"http_url":         "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"uuid_rfc4122":     "uuid",
+	"uuid4_rfc4122":    "uuid",
+	"ipv4":             "ipv4",
+	"ip4_addr":         "ipv4",
+	"ipv6":             "ipv6",
+	"ip6_addr":         "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+	"base64":           "byte",
+}
+
+// validatorPatterns maps validator's character class rules to patterns.
+var validatorPatterns = map[string]string{
+	"alpha":       "^[a-zA-Z]+$",
+	"alphanum":    "^[a-zA-Z0-9]+$",
+	"numeric":     "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+	"number":      "^[0-9]+$",
+	"hexadecimal": "^(0[xX])?[0-9a-fA-F]+$",
+	"e164":        "^\\+[1-9]?[0-9]{7,14}$",
+}
+
+// fieldValidation is what a validator tag says about a field's presence.
+type fieldValidation struct {
+	required bool // required
+	optional bool // omitempty without required
+}
+
+// applyFieldValidation applies the go-playground/validator rules of field, from
+// its validate tag or gin's binding tag, to its schema:
+//
+//	Name  string   `json:"name" validate:"required,min=1,max=100"`
+//	Tags  []string `json:"tags" validate:"max=5,unique,dive,oneof=a b c"`
+func applyFieldValidation(schema map[string]interface{}, field *ast.Field) fieldValidation {
+	tag := extractTag(field, "validate")
+	if tag == "" {
+		tag = extractTag(field, "binding")
+	}
+	if tag == "" || tag == "-" {
+		return fieldValidation{}
+	}
+	v := applyValidateRules(schema, strings.Split(tag, ","))
+	if v.required {
+		v.optional = false
+	}
+	return v
+}
+
+// applyValidateRules applies rules to schema, descending into the element
+// schema at dive. Alternatives (a|b) and map key rules are not translated.
+func applyValidateRules(schema map[string]interface{}, rules []string) fieldValidation {
+	var v fieldValidation
+	_, isRef := schema["$ref"]
+	schemaType, _ := schema["type"].(string)
+	inKeys := false
+	for i, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		name, arg := rule, ""
+		if idx := strings.Index(rule, "="); idx >= 0 {
+			name, arg = rule[:idx], rule[idx+1:]
+		}
+		switch {
+		case name == "keys":
+			inKeys = true
+			continue
+		case name == "endkeys":
+			inKeys = false
+			continue
+		case inKeys || strings.Contains(rule, "|"):
+			continue
+		}
+		switch name {
+		case "required":
+			v.required = true
+		case "omitempty":
+			v.optional = true
+		case "dive":
+			if elem := elementSchema(schema); elem != nil {
+				applyValidateRules(elem, rules[i+1:])
+			}
+			return v
+		}
+		if isRef {
+			continue
+		}
+		switch name {
+		case "min", "gte":
+			setValidateBound(schema, schemaType, arg, true, false)
+		case "gt":
+			setValidateBound(schema, schemaType, arg, true, true)
+		case "max", "lte":
+			setValidateBound(schema, schemaType, arg, false, false)
+		case "lt":
+			setValidateBound(schema, schemaType, arg, false, true)
+		case "len":
+			setValidateBound(schema, schemaType, arg, true, false)
+			setValidateBound(schema, schemaType, arg, false, false)
+		case "oneof":
+			if schemaType == "string" || schemaType == "integer" || schemaType == "number" {
+				var values []interface{}
+				for _, value := range splitOneOf(arg) {
+					values = append(values, annotationValue(value, schemaType))
+				}
+				schema["enum"] = values
+			}
+		case "unique":
+			if schemaType == "array" {
+				schema["uniqueItems"] = true
+			}
+		case "datetime":
+			if schemaType == "string" {
+				schema["format"] = "date-time"
+				if arg == "2006-01-02" {
+					schema["format"] = "date"
+				}
+			}
+		case "startswith":
+			if schemaType == "string" && arg != "" {
+				schema["pattern"] = "^" + regexp.QuoteMeta(arg)
+			}
+		case "endswith":
+			if schemaType == "string" && arg != "" {
+				schema["pattern"] = regexp.QuoteMeta(arg) + "$"
+			}
+		default:
+			if schemaType != "string" {
+				continue
+			}
+			if format, ok := validatorFormats[name]; ok {
+				schema["format"] = format
+			} else if pattern, ok := validatorPatterns[name]; ok {
+				schema["pattern"] = pattern
+			}
+		}
+	}
+	return v
+}
+
+// setValidateBound sets the lower or upper bound given by a min/max style rule.
+// Bounds count characters for strings, elements for arrays and entries for
+// maps; exclusive bounds (gt, lt) are made inclusive for those counts.
+func setValidateBound(schema map[string]interface{}, schemaType, arg string, lower, exclusive bool) {
+	switch schemaType {
+	case "integer", "number":
+		value, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return
+		}
+		key, flag := "maximum", "exclusiveMaximum"
+		if lower {
+			key, flag = "minimum", "exclusiveMinimum"
+		}
+		schema[key] = value
+		if exclusive {
+			schema[flag] = true
+		}
+	case "string", "array", "object":
+		count, err := strconv.Atoi(arg)
+		if err != nil {
+			return
+		}
+		if exclusive && lower {
+			count++
+		} else if exclusive {
+			count--
+		}
+		keys := map[string][2]string{
+			"string": {"minLength", "maxLength"},
+			"array":  {"minItems", "maxItems"},
+			"object": {"minProperties", "maxProperties"},
+		}[schemaType]
+		if lower {
+			schema[keys[0]] = count
+		} else {
+			schema[keys[1]] = count
+		}
+	}
+}
+
+// elementSchema returns the inline schema of the elements of an array or the
+// values of a map.
+func elementSchema(schema map[string]interface{}) map[string]interface{} {
+	for _, key := range []string{"items", "additionalProperties"} {
+		if elem, ok := schema[key].(map[string]interface{}); ok {
+			return elem
+		}
+	}
+	return nil
+}
+
+// splitOneOf splits the values of a oneof rule, which are separated by spaces
+// and may be single-quoted to contain them: oneof='red green' 'blue'.
+func splitOneOf(arg string) []string {
+	var values []string
+	for arg = strings.TrimSpace(arg); arg != ""; arg = strings.TrimSpace(arg) {
+		if arg[0] == '\'' {
+			if end := strings.Index(arg[1:], "'"); end >= 0 {
+				values = append(values, arg[1:end+1])
+				arg = arg[end+2:]
+				continue
+			}
+		}
+		end := strings.IndexAny(arg, " \t")
+		if end < 0 {
+			end = len(arg)
+		}
+		values = append(values, arg[:end])
+		arg = arg[end:]
+	}
+	return values
+}
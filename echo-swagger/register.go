@@ -0,0 +1,90 @@
+package echoswagger
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/webasoo/docoo/redoc"
+	"github.com/webasoo/docoo/scalar"
+	"github.com/webasoo/docoo/swagger"
+)
+
+// Router is implemented by both *echo.Echo and *echo.Group.
+type Router interface {
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// Handler adapts the Swagger UI handler to Echo.
+func Handler(spec []byte) echo.HandlerFunc {
+	return echo.WrapHandler(swagger.Handler(spec))
+}
+
+// Register attaches GET handlers for /swagger and /swagger/*.
+func Register(router Router, spec []byte) {
+	mount(router, "/swagger", Handler(spec))
+}
+
+// RegisterFile loads an OpenAPI document from disk and mounts the Swagger UI routes for Echo routers.
+func RegisterFile(router Router, path string) error {
+	data, err := readSpec(path)
+	if err != nil {
+		return err
+	}
+	Register(router, data)
+	return nil
+}
+
+// RedocHandler adapts the Redoc viewer to Echo.
+func RedocHandler(spec []byte) echo.HandlerFunc {
+	return echo.WrapHandler(redoc.Handler(spec))
+}
+
+// RegisterRedoc attaches GET handlers for /redoc and /redoc/*.
+func RegisterRedoc(router Router, spec []byte) {
+	mount(router, "/redoc", RedocHandler(spec))
+}
+
+// RegisterRedocFile loads an OpenAPI document from disk and mounts the Redoc routes.
+func RegisterRedocFile(router Router, path string) error {
+	data, err := readSpec(path)
+	if err != nil {
+		return err
+	}
+	RegisterRedoc(router, data)
+	return nil
+}
+
+// ScalarHandler adapts the Scalar API reference to Echo.
+func ScalarHandler(spec []byte) echo.HandlerFunc {
+	return echo.WrapHandler(scalar.Handler(spec))
+}
+
+// RegisterScalar attaches GET handlers for /scalar and /scalar/*.
+func RegisterScalar(router Router, spec []byte) {
+	mount(router, "/scalar", ScalarHandler(spec))
+}
+
+// RegisterScalarFile loads an OpenAPI document from disk and mounts the Scalar routes.
+func RegisterScalarFile(router Router, path string) error {
+	data, err := readSpec(path)
+	if err != nil {
+		return err
+	}
+	RegisterScalar(router, data)
+	return nil
+}
+
+func mount(router Router, base string, handler echo.HandlerFunc) {
+	router.GET(base, handler)
+	router.GET(base+"/*", handler)
+}
+
+func readSpec(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("echoswagger: read spec %q: %w", path, err)
+	}
+	return data, nil
+}
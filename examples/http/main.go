@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
 
@@ -16,6 +17,9 @@ func main() {
 		log.Fatalf("build spec: %v", err)
 	}
 
+	http.HandleFunc("GET /todos", listTodos)
+	http.HandleFunc("GET /todos/{id}", getTodo)
+
 	if err := swagger.RegisterFile(path); err != nil {
 		log.Fatalf("register swagger: %v", err)
 	}
@@ -33,3 +37,23 @@ func main() {
 
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
+
+// @Summary List todos
+// @Tags Todos
+func listTodos(w http.ResponseWriter, r *http.Request) {
+	todos := []todo{{ID: "1", Title: "Write docs"}}
+	json.NewEncoder(w).Encode(todos)
+}
+
+// @Summary Fetch todo
+// @Tags Todos
+func getTodo(w http.ResponseWriter, r *http.Request) {
+	item := todo{ID: r.PathValue("id"), Title: "Write docs"}
+	json.NewEncoder(w).Encode(item)
+}
+
+// todo is the payload returned by the todo endpoints.
+type todo struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
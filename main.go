@@ -70,10 +70,17 @@ func runGenerate(args []string) error {
 	root := fs.String("root", "", "workspace root to scan (defaults to current module)")
 	title := fs.String("title", "", "override the generated document title")
 	enableAuthUI := fs.Bool("enable-auth", false, "include Bearer auth + global security in generated openapi.json")
+	typeCheck := fs.Bool("typecheck", false, "resolve types with go/packages (slower; falls back to AST analysis if loading fails)")
+	embeddedAllOf := fs.Bool("embed-allof", false, "compose embedded structs with allOf instead of flattening their fields")
+	verbose := fs.Bool("v", false, "report skipped route candidates and other diagnostics on stderr")
 	var routes stringSliceFlag
 	var skips stringSliceFlag
+	var frameworks stringSliceFlag
+	var authMiddleware stringSliceFlag
 	fs.Var(&routes, "route", "additional directory to scan for routes (repeatable)")
 	fs.Var(&skips, "skip", "path prefix to exclude from documentation (repeatable)")
+	fs.Var(&frameworks, "framework", "framework to scan for, e.g. fiber, chi, gin, echo, nethttp (repeatable; default auto-detect)")
+	fs.Var(&authMiddleware, "auth-middleware", "middleware=scheme, e.g. jwtware.New=BearerAuth; routes behind it require the scheme (BearerAuth or BasicAuth; repeatable)")
 
 	fs.Usage = func() {
 		fmt.Fprintf(fs.Output(), "Usage: %s generate [flags]\n\n", commandName())
@@ -90,9 +97,27 @@ func runGenerate(args []string) error {
 	}
 
 	cfg := core.ProjectConfig{
-		RoutePaths:   routes,
-		SkipPrefixes: skips,
-		EnableAuthUI: *enableAuthUI,
+		RoutePaths:    routes,
+		SkipPrefixes:  skips,
+		EnableAuthUI:  *enableAuthUI,
+		Frameworks:    frameworks,
+		TypeCheck:     *typeCheck,
+		EmbeddedAllOf: *embeddedAllOf,
+	}
+	for _, entry := range authMiddleware {
+		name, scheme, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(name) == "" || strings.TrimSpace(scheme) == "" {
+			return fmt.Errorf("invalid -auth-middleware %q: want middleware=scheme", entry)
+		}
+		if cfg.SecurityMiddleware == nil {
+			cfg.SecurityMiddleware = make(map[string]string)
+		}
+		cfg.SecurityMiddleware[strings.TrimSpace(name)] = strings.TrimSpace(scheme)
+	}
+	if *verbose {
+		cfg.OnDiagnostic = func(d core.Diagnostic) {
+			fmt.Fprintln(os.Stderr, d)
+		}
 	}
 	if strings.TrimSpace(*root) != "" {
 		cfg.WorkspaceRoot = strings.TrimSpace(*root)